@@ -0,0 +1,13 @@
+ephemeral "supabase_apikeys" "production" {
+  project_ref = "mayuaycdtijbctgqbycg"
+}
+
+resource "vault_kv_secret_v2" "supabase" {
+  mount = "secret"
+  name  = "supabase/production"
+
+  data_json_wo = jsonencode({
+    service_role_key = ephemeral.supabase_apikeys.production.service_role_key
+  })
+  data_json_wo_version = 1
+}
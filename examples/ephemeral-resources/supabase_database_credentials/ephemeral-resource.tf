@@ -0,0 +1,10 @@
+ephemeral "supabase_database_credentials" "preview" {
+  branch_id = supabase_branch.preview.id
+}
+
+provider "postgresql" {
+  host     = ephemeral.supabase_database_credentials.preview.host
+  port     = ephemeral.supabase_database_credentials.preview.port
+  username = ephemeral.supabase_database_credentials.preview.user
+  password = ephemeral.supabase_database_credentials.preview.password
+}
@@ -0,0 +1,11 @@
+ephemeral "supabase_temporary_db_role" "migrations" {
+  project_ref = "mayuaycdtijbctgqbycg"
+}
+
+provider "postgresql" {
+  host     = ephemeral.supabase_temporary_db_role.migrations.host
+  port     = ephemeral.supabase_temporary_db_role.migrations.port
+  database = ephemeral.supabase_temporary_db_role.migrations.database
+  username = ephemeral.supabase_temporary_db_role.migrations.role
+  password = ephemeral.supabase_temporary_db_role.migrations.password
+}
@@ -0,0 +1,10 @@
+output "database_url" {
+  value = provider::supabase::connection_string(
+    supabase_project.production.id,
+    "postgres",
+    var.database_password,
+    "transaction",
+    var.pooler_region, # such as aws-0-us-east-1
+  )
+  sensitive = true
+}
@@ -0,0 +1,4 @@
+locals {
+  # mayuaycdtijbctgqbycg
+  project_ref = provider::supabase::parse_ref("https://mayuaycdtijbctgqbycg.supabase.co")
+}
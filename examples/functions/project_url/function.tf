@@ -0,0 +1,3 @@
+output "supabase_url" {
+  value = provider::supabase::project_url(supabase_project.production.id)
+}
@@ -0,0 +1,22 @@
+resource "supabase_api_key" "web" {
+  project_ref = "mayuaycdtijbctgqbycg"
+  name        = "web"
+  type        = "publishable"
+  description = "Key shipped with the web client"
+}
+
+resource "supabase_api_key" "backend" {
+  project_ref = "mayuaycdtijbctgqbycg"
+  name        = "backend"
+  type        = "secret"
+  description = "Key used by the backend workers"
+
+  # Change any value to rotate the key.
+  rotation_triggers = {
+    rotated_at = "2025-01-01"
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
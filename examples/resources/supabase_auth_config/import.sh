@@ -0,0 +1 @@
+terraform import supabase_auth_config.production mayuaycdtijbctgqbycg
@@ -0,0 +1,28 @@
+resource "supabase_auth_config" "production" {
+  project_ref = "mayuaycdtijbctgqbycg"
+
+  site_url             = "https://example.com"
+  mailer_otp_exp       = 3600
+  mfa_phone_otp_length = 6
+  sms_otp_length       = 6
+
+  # Write-only secrets are never stored in state, bump the version to send a
+  # new value (Terraform 1.11 or later)
+  smtp_pass_wo         = "your_smtp_password"
+  smtp_pass_wo_version = 1
+
+  external_github = {
+    enabled           = true
+    client_id         = "your_github_client_id"
+    secret_wo         = "your_github_client_secret"
+    secret_wo_version = 1
+  }
+}
+
+# Move the auth block of an existing supabase_settings resource. Terraform
+# allows a single moved block per source address, so import the other
+# sections of supabase_settings.production into their resources instead.
+moved {
+  from = supabase_settings.production
+  to   = supabase_auth_config.production
+}
@@ -0,0 +1,19 @@
+# Step 1: create a standby key and let clients pick up its public key.
+resource "supabase_jwt_signing_key" "next" {
+  project_ref = "mayuaycdtijbctgqbycg"
+  algorithm   = "ES256"
+  status      = "standby"
+}
+
+# Step 2: promote the standby key by setting status = "in_use", and set the
+# key it replaces to "previously_used".
+# Step 3: once tokens signed with the previous key have expired, set its
+# status to "revoked" or remove it from the configuration.
+
+data "supabase_jwt_signing_keys" "all" {
+  project_ref = "mayuaycdtijbctgqbycg"
+}
+
+output "jwks" {
+  value = data.supabase_jwt_signing_keys.all.jwks
+}
@@ -0,0 +1 @@
+terraform import supabase_network_restrictions.production mayuaycdtijbctgqbycg
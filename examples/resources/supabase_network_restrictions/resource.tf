@@ -0,0 +1,8 @@
+resource "supabase_network_restrictions" "production" {
+  project_ref = "mayuaycdtijbctgqbycg"
+
+  allowed_cidrs = [
+    "203.0.113.0/24",
+    "2001:db8::/32",
+  ]
+}
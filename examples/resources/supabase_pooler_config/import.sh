@@ -0,0 +1 @@
+terraform import supabase_pooler_config.production mayuaycdtijbctgqbycg
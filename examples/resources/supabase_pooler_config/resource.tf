@@ -0,0 +1,5 @@
+resource "supabase_pooler_config" "production" {
+  project_ref = "mayuaycdtijbctgqbycg"
+
+  default_pool_size = 20
+}
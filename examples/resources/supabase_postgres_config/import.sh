@@ -0,0 +1 @@
+terraform import supabase_postgres_config.production mayuaycdtijbctgqbycg
@@ -0,0 +1,7 @@
+resource "supabase_postgres_config" "production" {
+  project_ref = "mayuaycdtijbctgqbycg"
+
+  statement_timeout = "10s"
+  work_mem          = "8MB"
+  shared_buffers    = "256MB"
+}
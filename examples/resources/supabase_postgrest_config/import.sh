@@ -0,0 +1 @@
+terraform import supabase_postgrest_config.production mayuaycdtijbctgqbycg
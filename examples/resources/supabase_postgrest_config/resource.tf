@@ -0,0 +1,7 @@
+resource "supabase_postgrest_config" "production" {
+  project_ref = "mayuaycdtijbctgqbycg"
+
+  db_schema            = "public,storage,graphql_public"
+  db_extra_search_path = "public,extensions"
+  max_rows             = 1000
+}
@@ -0,0 +1,5 @@
+# Import all sections of the project settings
+terraform import supabase_settings.production mayuaycdtijbctgqbycg
+
+# Import only the listed sections
+terraform import supabase_settings.production mayuaycdtijbctgqbycg:auth,database
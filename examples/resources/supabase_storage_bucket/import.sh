@@ -0,0 +1 @@
+terraform import supabase_storage_bucket.user_avatars abcdefghijklmnopqrst/user-avatars
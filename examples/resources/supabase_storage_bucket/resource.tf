@@ -27,6 +27,14 @@ resource "supabase_storage_bucket" "media_uploads" {
   allowed_mime_types = ["image/*", "video/mp4", "video/quicktime"]
 }
 
+# Delete all objects when the bucket is destroyed
+resource "supabase_storage_bucket" "build_cache" {
+  project_ref   = "abcdefghijklmnopqrst"
+  name          = "build-cache"
+  public        = false
+  force_destroy = true
+}
+
 # Output bucket information
 output "user_avatars_id" {
   value = supabase_storage_bucket.user_avatars.id
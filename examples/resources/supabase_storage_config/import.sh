@@ -0,0 +1 @@
+terraform import supabase_storage_config.production mayuaycdtijbctgqbycg
@@ -0,0 +1,11 @@
+resource "supabase_storage_config" "production" {
+  project_ref = "mayuaycdtijbctgqbycg"
+
+  file_size_limit = 52428800 # 50MB in bytes
+
+  features = {
+    image_transformation = {
+      enabled = true
+    }
+  }
+}
@@ -0,0 +1,9 @@
+resource "supabase_storage_directory_sync" "static" {
+  project_ref    = "mayuaycdtijbctgqbycg"
+  bucket         = "static"
+  prefix         = "site/"
+  source_dir     = "${path.module}/dist"
+  exclude        = ["**/*.map", ".DS_Store"]
+  delete_removed = true
+  parallelism    = 16
+}
@@ -0,0 +1,16 @@
+resource "supabase_storage_object" "default_avatar" {
+  project_ref   = "mayuaycdtijbctgqbycg"
+  bucket        = "user-avatars"
+  path          = "defaults/avatar.png"
+  source        = "${path.module}/assets/avatar.png"
+  cache_control = "max-age=86400"
+}
+
+resource "supabase_storage_object" "app_config" {
+  project_ref  = "mayuaycdtijbctgqbycg"
+  bucket       = "config"
+  path         = "app.json"
+  content      = jsonencode({ theme = "dark" })
+  content_type = "application/json"
+  upsert       = true
+}
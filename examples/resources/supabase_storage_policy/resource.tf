@@ -0,0 +1,21 @@
+resource "supabase_storage_bucket" "avatars" {
+  project_ref = "mayuaycdtijbctgqbycg"
+  name        = "avatars"
+}
+
+resource "supabase_storage_policy" "avatars_read" {
+  project_ref = "mayuaycdtijbctgqbycg"
+  name        = "Avatars are readable by signed in users"
+  bucket      = supabase_storage_bucket.avatars.name
+  operation   = "select"
+  roles       = ["authenticated"]
+}
+
+resource "supabase_storage_policy" "avatars_upload" {
+  project_ref = "mayuaycdtijbctgqbycg"
+  name        = "Users upload their own avatar"
+  bucket      = supabase_storage_bucket.avatars.name
+  operation   = "insert"
+  roles       = ["authenticated"]
+  condition   = "(storage.foldername(name))[1] = (select auth.uid()::text)"
+}
@@ -0,0 +1,15 @@
+resource "supabase_third_party_auth" "clerk" {
+  project_ref  = "mayuaycdtijbctgqbycg"
+  clerk_domain = "clerk.example.com"
+}
+
+resource "supabase_third_party_auth" "cognito" {
+  project_ref              = "mayuaycdtijbctgqbycg"
+  cognito_user_pool_id     = "us-east-1_AbCdEfGhI"
+  cognito_user_pool_region = "us-east-1"
+}
+
+resource "supabase_third_party_auth" "custom" {
+  project_ref     = "mayuaycdtijbctgqbycg"
+  oidc_issuer_url = "https://issuer.example.com"
+}
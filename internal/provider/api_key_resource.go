@@ -0,0 +1,392 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
+	"github.com/supabase/cli/pkg/api"
+)
+
+var (
+	_ resource.Resource                = &APIKeyResource{}
+	_ resource.ResourceWithConfigure   = &APIKeyResource{}
+	_ resource.ResourceWithImportState = &APIKeyResource{}
+	_ resource.ResourceWithModifyPlan  = &APIKeyResource{}
+)
+
+func NewAPIKeyResource() resource.Resource {
+	return &APIKeyResource{}
+}
+
+// APIKeyResource manages new-style publishable and secret API keys.
+type APIKeyResource struct {
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
+}
+
+type APIKeyResourceModel struct {
+	ProjectRef       types.String `tfsdk:"project_ref"`
+	Id               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Type             types.String `tfsdk:"type"`
+	Description      types.String `tfsdk:"description"`
+	SecretJwtRole    types.String `tfsdk:"secret_jwt_role"`
+	RotationTriggers types.Map    `tfsdk:"rotation_triggers"`
+	ApiKey           types.String `tfsdk:"api_key"`
+	Prefix           types.String `tfsdk:"prefix"`
+	InsertedAt       types.String `tfsdk:"inserted_at"`
+	UpdatedAt        types.String `tfsdk:"updated_at"`
+}
+
+// apiKeyCreateBody adds the key name, which the generated client does not
+// model yet, to the create request body.
+type apiKeyCreateBody struct {
+	api.CreateApiKeyBody
+	Name string `json:"name"`
+}
+
+func (r *APIKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_key"
+}
+
+func (r *APIKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+
+Manages a new-style publishable or secret API key for a Supabase project.
+
+Changing ` + "`rotation_triggers`" + ` replaces the key. Combine it with ` + "`create_before_destroy`" + ` to roll a key without downtime.
+
+Refer to the [Supabase API keys documentation](https://supabase.com/docs/guides/api/api-keys) for more information.
+
+## Example Usage
+
+~~~hcl
+resource "supabase_api_key" "backend" {
+  project_ref = "abcdefghijklmnopqrst"
+  name        = "backend"
+  type        = "secret"
+  description = "Key used by the backend workers"
+
+  rotation_triggers = {
+    rotated_at = "2025-01-01"
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+~~~
+`,
+		Attributes: map[string]schema.Attribute{
+			"project_ref": schema.StringAttribute{
+				MarkdownDescription: "Project reference ID. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "API key ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "API key name (must be unique within project)",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "API key type (`publishable` or `secret`)",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(api.CreateApiKeyBodyTypePublishable),
+						string(api.CreateApiKeyBodyTypeSecret),
+					),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "API key description",
+				Optional:            true,
+			},
+			"secret_jwt_role": schema.StringAttribute{
+				MarkdownDescription: "Postgres role assumed by requests made with a secret key (defaults to `service_role` on the server)",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotation_triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, rotates the key by replacing it",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"api_key": schema.StringAttribute{
+				MarkdownDescription: "Revealed API key value",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"prefix": schema.StringAttribute{
+				MarkdownDescription: "Non-secret prefix of the API key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"inserted_at": schema.StringAttribute{
+				MarkdownDescription: "When the API key was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "When the API key was last updated",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *APIKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*settings.SupabaseProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *settings.SupabaseProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(providerData.RequireManagementAPI("supabase_api_key")...)
+	r.client = providerData.ManagementClient
+	r.providerData = providerData
+}
+
+// ModifyPlan falls back to the provider project_ref when the resource omits it.
+func (r *APIKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.PlanProjectRef(ctx, req, resp)
+}
+
+func (r *APIKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(settings.SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	var data APIKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := apiKeyCreateBody{
+		CreateApiKeyBody: api.CreateApiKeyBody{
+			Type:              api.CreateApiKeyBodyType(data.Type.ValueString()),
+			Description:       data.Description.ValueStringPointer(),
+			SecretJwtTemplate: apiKeySecretJwtTemplate(data.SecretJwtRole),
+		},
+		Name: data.Name.ValueString(),
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to encode API key request: %s", err))
+		return
+	}
+
+	httpResp, err := r.client.CreateApiKeyWithBodyWithResponse(
+		ctx,
+		data.ProjectRef.ValueString(),
+		&api.CreateApiKeyParams{Reveal: true},
+		"application/json",
+		bytes.NewReader(payload),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create API key: %s", err))
+		return
+	}
+
+	if httpResp.JSON201 == nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create API key, got status %d: %s", httpResp.StatusCode(), httpResp.Body))
+		return
+	}
+
+	updateDataFromAPIKey(&data, httpResp.JSON201)
+
+	tflog.Trace(ctx, "created API key")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *APIKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Skipped resources keep their prior state
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
+	var data APIKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.GetApiKeyWithResponse(ctx, data.ProjectRef.ValueString(), data.Id.ValueString(), &api.GetApiKeyParams{Reveal: true})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API key: %s", err))
+		return
+	}
+
+	if httpResp.StatusCode() == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if httpResp.JSON200 == nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to read API key, got status %d: %s", httpResp.StatusCode(), httpResp.Body))
+		return
+	}
+
+	updateDataFromAPIKey(&data, httpResp.JSON200)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *APIKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(settings.SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	var data APIKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := api.UpdateApiKeyBody{
+		Description:       data.Description.ValueStringPointer(),
+		SecretJwtTemplate: apiKeySecretJwtTemplate(data.SecretJwtRole),
+	}
+
+	httpResp, err := r.client.UpdateApiKeyWithResponse(ctx, data.ProjectRef.ValueString(), data.Id.ValueString(), &api.UpdateApiKeyParams{Reveal: true}, body)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update API key: %s", err))
+		return
+	}
+
+	if httpResp.JSON200 == nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update API key, got status %d: %s", httpResp.StatusCode(), httpResp.Body))
+		return
+	}
+
+	updateDataFromAPIKey(&data, httpResp.JSON200)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *APIKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
+	var data APIKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.DeleteApiKeyWithResponse(ctx, data.ProjectRef.ValueString(), data.Id.ValueString(), &api.DeleteApiKeyParams{})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete API key: %s", err))
+		return
+	}
+
+	if httpResp.StatusCode() != http.StatusOK && httpResp.StatusCode() != http.StatusNotFound {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete API key, got status %d: %s", httpResp.StatusCode(), httpResp.Body))
+		return
+	}
+}
+
+func (r *APIKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the format project_ref/id, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_ref"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+func apiKeySecretJwtTemplate(role types.String) *api.ApiKeySecretJWTTemplate {
+	if role.IsNull() || role.IsUnknown() {
+		return nil
+	}
+	return &api.ApiKeySecretJWTTemplate{Role: role.ValueString()}
+}
+
+func updateDataFromAPIKey(data *APIKeyResourceModel, key *api.ApiKeyResponse) {
+	if key.Id != nil {
+		data.Id = types.StringValue(*key.Id)
+	}
+	data.Name = types.StringValue(key.Name)
+	data.ApiKey = types.StringValue(key.ApiKey)
+	data.Prefix = types.StringPointerValue(key.Prefix)
+	data.InsertedAt = types.StringPointerValue(key.InsertedAt)
+	data.UpdatedAt = types.StringPointerValue(key.UpdatedAt)
+
+	if key.Type != nil {
+		data.Type = types.StringValue(string(*key.Type))
+	}
+
+	data.Description = types.StringPointerValue(key.Description)
+
+	if key.SecretJwtTemplate != nil {
+		data.SecretJwtRole = types.StringValue(key.SecretJwtTemplate.Role)
+	} else {
+		data.SecretJwtRole = types.StringNull()
+	}
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccAPIKeyResource(t *testing.T) {
+	defer gock.OffAll()
+
+	secretType := api.ApiKeyResponseTypeSecret
+
+	// Mock API responses for API key CRUD operations
+	gock.New("https://api.supabase.com").
+		Post("/v1/projects/mayuaycdtijbctgqbycg/api-keys").
+		MatchParam("reveal", "true").
+		Reply(http.StatusCreated).
+		JSON(api.ApiKeyResponse{
+			Id:                Ptr("key-123"),
+			Name:              "backend",
+			Type:              &secretType,
+			ApiKey:            "sb_secret_abc123",
+			Prefix:            Ptr("sb_secret_abc"),
+			Description:       Ptr("Backend key"),
+			SecretJwtTemplate: &api.ApiKeySecretJWTTemplate{Role: "service_role"},
+			InsertedAt:        Ptr("2024-01-01T00:00:00Z"),
+			UpdatedAt:         Ptr("2024-01-01T00:00:00Z"),
+		})
+
+	gock.New("https://api.supabase.com").
+		Get("/v1/projects/mayuaycdtijbctgqbycg/api-keys/key-123").
+		MatchParam("reveal", "true").
+		Times(3).
+		Reply(http.StatusOK).
+		JSON(api.ApiKeyResponse{
+			Id:                Ptr("key-123"),
+			Name:              "backend",
+			Type:              &secretType,
+			ApiKey:            "sb_secret_abc123",
+			Prefix:            Ptr("sb_secret_abc"),
+			Description:       Ptr("Backend key"),
+			SecretJwtTemplate: &api.ApiKeySecretJWTTemplate{Role: "service_role"},
+			InsertedAt:        Ptr("2024-01-01T00:00:00Z"),
+			UpdatedAt:         Ptr("2024-01-01T00:00:00Z"),
+		})
+
+	gock.New("https://api.supabase.com").
+		Patch("/v1/projects/mayuaycdtijbctgqbycg/api-keys/key-123").
+		Reply(http.StatusOK).
+		JSON(api.ApiKeyResponse{
+			Id:                Ptr("key-123"),
+			Name:              "backend",
+			Type:              &secretType,
+			ApiKey:            "sb_secret_abc123",
+			Prefix:            Ptr("sb_secret_abc"),
+			Description:       Ptr("Backend workers"),
+			SecretJwtTemplate: &api.ApiKeySecretJWTTemplate{Role: "service_role"},
+			InsertedAt:        Ptr("2024-01-01T00:00:00Z"),
+			UpdatedAt:         Ptr("2024-01-01T01:00:00Z"),
+		})
+
+	gock.New("https://api.supabase.com").
+		Get("/v1/projects/mayuaycdtijbctgqbycg/api-keys/key-123").
+		MatchParam("reveal", "true").
+		Times(3).
+		Reply(http.StatusOK).
+		JSON(api.ApiKeyResponse{
+			Id:                Ptr("key-123"),
+			Name:              "backend",
+			Type:              &secretType,
+			ApiKey:            "sb_secret_abc123",
+			Prefix:            Ptr("sb_secret_abc"),
+			Description:       Ptr("Backend workers"),
+			SecretJwtTemplate: &api.ApiKeySecretJWTTemplate{Role: "service_role"},
+			InsertedAt:        Ptr("2024-01-01T00:00:00Z"),
+			UpdatedAt:         Ptr("2024-01-01T01:00:00Z"),
+		})
+
+	gock.New("https://api.supabase.com").
+		Delete("/v1/projects/mayuaycdtijbctgqbycg/api-keys/key-123").
+		Reply(http.StatusOK).
+		JSON(api.ApiKeyResponse{
+			Id:   Ptr("key-123"),
+			Name: "backend",
+		})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAPIKeyResourceConfig("Backend key"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("supabase_api_key.test", "id", "key-123"),
+					resource.TestCheckResourceAttr("supabase_api_key.test", "type", "secret"),
+					resource.TestCheckResourceAttr("supabase_api_key.test", "api_key", "sb_secret_abc123"),
+					resource.TestCheckResourceAttr("supabase_api_key.test", "secret_jwt_role", "service_role"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "supabase_api_key.test",
+				ImportState:             true,
+				ImportStateId:           "mayuaycdtijbctgqbycg/key-123",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rotation_triggers"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccAPIKeyResourceConfig("Backend workers"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("supabase_api_key.test", "description", "Backend workers"),
+					resource.TestCheckResourceAttr("supabase_api_key.test", "updated_at", "2024-01-01T01:00:00Z"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccAPIKeyResourceConfig(description string) string {
+	return `
+resource "supabase_api_key" "test" {
+  project_ref = "mayuaycdtijbctgqbycg"
+  name        = "backend"
+  type        = "secret"
+  description = "` + description + `"
+}
+`
+}
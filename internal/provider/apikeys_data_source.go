@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
@@ -21,14 +22,25 @@ func NewAPIKeysDataSource() datasource.DataSource {
 
 // APIKeysDataSource defines the data source implementation.
 type APIKeysDataSource struct {
-	client *api.ClientWithResponses
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
 }
 
 // APIKeysDataSourceModel describes the data source data model.
 type APIKeysDataSourceModel struct {
-	ProjectRef     types.String `tfsdk:"project_ref"`
-	AnonKey        types.String `tfsdk:"anon_key"`
-	ServiceRoleKey types.String `tfsdk:"service_role_key"`
+	ProjectRef     types.String  `tfsdk:"project_ref"`
+	AnonKey        types.String  `tfsdk:"anon_key"`
+	ServiceRoleKey types.String  `tfsdk:"service_role_key"`
+	Keys           []APIKeyModel `tfsdk:"keys"`
+}
+
+type APIKeyModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	Description types.String `tfsdk:"description"`
+	Prefix      types.String `tfsdk:"prefix"`
+	ApiKey      types.String `tfsdk:"api_key"`
 }
 
 func (d *APIKeysDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -37,12 +49,13 @@ func (d *APIKeysDataSource) Metadata(ctx context.Context, req datasource.Metadat
 
 func (d *APIKeysDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "API Keys data source",
+		MarkdownDescription: "API Keys data source. In local mode, returns the keys of the local stack from `supabase/config.toml`.",
 
 		Attributes: map[string]schema.Attribute{
 			"project_ref": schema.StringAttribute{
-				MarkdownDescription: "Project reference ID",
-				Required:            true,
+				MarkdownDescription: "Project reference ID. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"anon_key": schema.StringAttribute{
 				MarkdownDescription: "Anonymous API key for the project",
@@ -54,6 +67,39 @@ func (d *APIKeysDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Computed:            true,
 				Sensitive:           true,
 			},
+			"keys": schema.ListNestedAttribute{
+				MarkdownDescription: "All API keys for the project, including legacy, publishable, and secret keys",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "API key ID (null for legacy keys)",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "API key name",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "API key type (`legacy`, `publishable`, or `secret`)",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "API key description",
+							Computed:            true,
+						},
+						"prefix": schema.StringAttribute{
+							MarkdownDescription: "Non-secret prefix of the API key",
+							Computed:            true,
+						},
+						"api_key": schema.StringAttribute{
+							MarkdownDescription: "Revealed API key value",
+							Computed:            true,
+							Sensitive:           true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -74,6 +120,7 @@ func (d *APIKeysDataSource) Configure(ctx context.Context, req datasource.Config
 	}
 
 	d.client = providerData.ManagementClient
+	d.providerData = providerData
 }
 
 func (d *APIKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -84,18 +131,43 @@ func (d *APIKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(d.providerData.ResolveProjectRef(&data.ProjectRef)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.providerData.SkipsManagementAPI() {
+		setLocalAPIKeys(d.providerData, &data)
+	} else {
+		resp.Diagnostics.Append(readAPIKeys(ctx, d.client, &data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
-	httpResp, err := d.client.V1GetProjectApiKeysWithResponse(ctx, data.ProjectRef.ValueString(), &api.V1GetProjectApiKeysParams{})
+	tflog.Trace(ctx, "read API keys")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readAPIKeys reads the revealed API keys of a project into data. It is
+// shared by the data source and the ephemeral resource.
+func readAPIKeys(ctx context.Context, client *api.ClientWithResponses, data *APIKeysDataSourceModel) diag.Diagnostics {
+	httpResp, err := client.V1GetProjectApiKeysWithResponse(ctx, data.ProjectRef.ValueString(), &api.V1GetProjectApiKeysParams{
+		Reveal: true, // Required to get actual API key values instead of masked ones
+	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API keys, got error: %s", err))
-		return
+		msg := fmt.Sprintf("Unable to read API keys, got error: %s", err)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
 	}
 
 	if httpResp.JSON200 == nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API keys, got status %d: %s", httpResp.StatusCode(), httpResp.Body))
-		return
+		msg := fmt.Sprintf("Unable to read API keys, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
 	}
 
+	data.Keys = make([]APIKeyModel, 0, len(*httpResp.JSON200))
 	for _, key := range *httpResp.JSON200 {
 		switch key.Name {
 		case "anon":
@@ -103,10 +175,43 @@ func (d *APIKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		case "service_role":
 			data.ServiceRoleKey = types.StringValue(key.ApiKey)
 		}
+
+		keyType := types.StringNull()
+		if key.Type != nil {
+			keyType = types.StringValue(string(*key.Type))
+		}
+
+		data.Keys = append(data.Keys, APIKeyModel{
+			Id:          types.StringPointerValue(key.Id),
+			Name:        types.StringValue(key.Name),
+			Type:        keyType,
+			Description: types.StringPointerValue(key.Description),
+			Prefix:      types.StringPointerValue(key.Prefix),
+			ApiKey:      types.StringValue(key.ApiKey),
+		})
 	}
 
-	tflog.Trace(ctx, "read API keys")
+	return nil
+}
 
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+// setLocalAPIKeys sets the legacy API keys of the local stack into data, which
+// the provider derives from supabase/config.toml in local mode.
+func setLocalAPIKeys(providerData *settings.SupabaseProviderData, data *APIKeysDataSourceModel) {
+	data.AnonKey = types.StringValue(providerData.LocalAnonKey)
+	data.ServiceRoleKey = types.StringValue(providerData.ServiceRoleKey)
+
+	data.Keys = make([]APIKeyModel, 0, 2)
+	for _, key := range []struct{ name, value string }{
+		{"anon", providerData.LocalAnonKey},
+		{"service_role", providerData.ServiceRoleKey},
+	} {
+		data.Keys = append(data.Keys, APIKeyModel{
+			Id:          types.StringNull(),
+			Name:        types.StringValue(key.name),
+			Type:        types.StringValue("legacy"),
+			Description: types.StringNull(),
+			Prefix:      types.StringNull(),
+			ApiKey:      types.StringValue(key.value),
+		})
+	}
 }
@@ -11,10 +11,13 @@ import (
 )
 
 func TestAccProjectAPIKeysDataSource(t *testing.T) {
+	publishableType := api.ApiKeyResponseTypePublishable
+
 	// Setup mock api
 	defer gock.OffAll()
 	gock.New("https://api.supabase.com").
 		Get("/v1/projects/mayuaycdtijbctgqbycg/api-keys").
+		MatchParam("reveal", "true").
 		Times(3).
 		Reply(http.StatusOK).
 		JSON([]api.ApiKeyResponse{
@@ -26,6 +29,13 @@ func TestAccProjectAPIKeysDataSource(t *testing.T) {
 				Name:   "service_role",
 				ApiKey: "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.service_role",
 			},
+			{
+				Id:     Ptr("key-123"),
+				Name:   "web",
+				Type:   &publishableType,
+				ApiKey: "sb_publishable_abc123",
+				Prefix: Ptr("sb_publishable_abc"),
+			},
 		})
 
 	resource.Test(t, resource.TestCase{
@@ -38,6 +48,47 @@ func TestAccProjectAPIKeysDataSource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.supabase_apikeys.production", "anon_key", "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.anon"),
 					resource.TestCheckResourceAttr("data.supabase_apikeys.production", "service_role_key", "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.service_role"),
+					resource.TestCheckResourceAttr("data.supabase_apikeys.production", "keys.#", "3"),
+					resource.TestCheckResourceAttr("data.supabase_apikeys.production", "keys.2.id", "key-123"),
+					resource.TestCheckResourceAttr("data.supabase_apikeys.production", "keys.2.type", "publishable"),
+					resource.TestCheckResourceAttr("data.supabase_apikeys.production", "keys.2.api_key", "sb_publishable_abc123"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectAPIKeysDataSourceProviderProjectRef(t *testing.T) {
+	// Setup mock api
+	defer gock.OffAll()
+	gock.New("https://api.supabase.com").
+		Get("/v1/projects/mayuaycdtijbctgqbycg/api-keys").
+		MatchParam("reveal", "true").
+		Times(3).
+		Reply(http.StatusOK).
+		JSON([]api.ApiKeyResponse{
+			{
+				Name:   "anon",
+				ApiKey: "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.anon",
+			},
+		})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The project is taken from the provider configuration
+			{
+				Config: `
+provider "supabase" {
+  project_ref = "mayuaycdtijbctgqbycg"
+}
+
+data "supabase_apikeys" "production" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.supabase_apikeys.production", "project_ref", "mayuaycdtijbctgqbycg"),
+					resource.TestCheckResourceAttr("data.supabase_apikeys.production", "anon_key", "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.anon"),
 				),
 			},
 		},
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
+	"github.com/supabase/cli/pkg/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &APIKeysEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &APIKeysEphemeralResource{}
+)
+
+func NewAPIKeysEphemeralResource() ephemeral.EphemeralResource {
+	return &APIKeysEphemeralResource{}
+}
+
+// APIKeysEphemeralResource returns the API keys of a project without
+// persisting them in state or plan.
+type APIKeysEphemeralResource struct {
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
+}
+
+func (r *APIKeysEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_apikeys"
+}
+
+func (r *APIKeysEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Returns the API keys of a project, like the ` + "`supabase_apikeys`" + ` data source, without persisting them in the state or plan. In local mode, returns the keys of the local stack from ` + "`supabase/config.toml`" + `. Requires Terraform 1.10 or later.
+
+## Example Usage
+
+~~~hcl
+ephemeral "supabase_apikeys" "production" {
+  project_ref = "mayuaycdtijbctgqbycg"
+}
+
+resource "vault_kv_secret_v2" "supabase" {
+  mount = "secret"
+  name  = "supabase/production"
+
+  data_json_wo = jsonencode({
+    service_role_key = ephemeral.supabase_apikeys.production.service_role_key
+  })
+  data_json_wo_version = 1
+}
+~~~
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"project_ref": schema.StringAttribute{
+				MarkdownDescription: "Project reference ID. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"anon_key": schema.StringAttribute{
+				MarkdownDescription: "Anonymous API key for the project",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"service_role_key": schema.StringAttribute{
+				MarkdownDescription: "Service role API key for the project",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"keys": schema.ListNestedAttribute{
+				MarkdownDescription: "All API keys for the project, including legacy, publishable, and secret keys",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "API key ID (null for legacy keys)",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "API key name",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "API key type (`legacy`, `publishable`, or `secret`)",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "API key description",
+							Computed:            true,
+						},
+						"prefix": schema.StringAttribute{
+							MarkdownDescription: "Non-secret prefix of the API key",
+							Computed:            true,
+						},
+						"api_key": schema.StringAttribute{
+							MarkdownDescription: "Revealed API key value",
+							Computed:            true,
+							Sensitive:           true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *APIKeysEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*settings.SupabaseProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *settings.SupabaseProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.ManagementClient
+	r.providerData = providerData
+}
+
+func (r *APIKeysEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data APIKeysDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.providerData.ResolveProjectRef(&data.ProjectRef)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.providerData.SkipsManagementAPI() {
+		setLocalAPIKeys(r.providerData, &data)
+	} else {
+		resp.Diagnostics.Append(readAPIKeys(ctx, r.client, &data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "opened API keys")
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -23,7 +23,8 @@ func NewBranchDataSource() datasource.DataSource {
 
 // BranchDataSource defines the data source implementation.
 type BranchDataSource struct {
-	client *api.ClientWithResponses
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
 }
 
 // BranchDataSourceModel describes the data source data model.
@@ -88,10 +89,17 @@ func (d *BranchDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
+	resp.Diagnostics.Append(providerData.RequireManagementAPI("supabase_branch")...)
 	d.client = providerData.ManagementClient
+	d.providerData = providerData
 }
 
 func (d *BranchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Skipped data sources return their configuration
+	if d.providerData.SkipsManagementAPI() {
+		return
+	}
+
 	var projectRef types.String
 
 	// Read Terraform configuration data into the model
@@ -29,7 +29,8 @@ func NewBranchResource() resource.Resource {
 
 // BranchResource defines the resource implementation.
 type BranchResource struct {
-	client *api.ClientWithResponses
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
 }
 
 type BranchDatabaseModel struct {
@@ -160,10 +161,17 @@ func (r *BranchResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
+	resp.Diagnostics.Append(providerData.RequireManagementAPI("supabase_branch")...)
 	r.client = providerData.ManagementClient
+	r.providerData = providerData
 }
 
 func (r *BranchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(settings.SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
 	var data BranchResourceModel
 
 	// Read Terraform plan data into the model
@@ -186,6 +194,11 @@ func (r *BranchResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *BranchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Skipped resources keep their prior state
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
 	var data BranchResourceModel
 
 	// Read Terraform prior state data into the model
@@ -204,6 +217,11 @@ func (r *BranchResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *BranchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(settings.SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
 	var data BranchResourceModel
 
 	// Read Terraform plan data into the model
@@ -222,6 +240,10 @@ func (r *BranchResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *BranchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
 	var data BranchResourceModel
 
 	// Read Terraform prior state data into the model
@@ -0,0 +1,403 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccPostgrestConfigResource(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New("https://api.supabase.com").
+		Patch("/v1/projects/mayuaycdtijbctgqbycg/postgrest").
+		Reply(http.StatusOK).
+		JSON(api.V1PostgrestConfigResponse{
+			DbExtraSearchPath: "public,extensions",
+			DbSchema:          "public,storage,graphql_public",
+			MaxRows:           1000,
+		})
+	gock.New("https://api.supabase.com").
+		Get("/v1/projects/mayuaycdtijbctgqbycg/postgrest").
+		Times(3).
+		Reply(http.StatusOK).
+		JSON(api.V1PostgrestConfigResponse{
+			DbExtraSearchPath: "public,extensions",
+			DbSchema:          "public,storage,graphql_public",
+			MaxRows:           1000,
+		})
+	gock.New("https://api.supabase.com").
+		Patch("/v1/projects/mayuaycdtijbctgqbycg/postgrest").
+		Reply(http.StatusOK).
+		JSON(api.V1PostgrestConfigResponse{
+			DbExtraSearchPath: "public,extensions",
+			DbSchema:          "public,storage,graphql_public",
+			MaxRows:           500,
+		})
+	gock.New("https://api.supabase.com").
+		Get("/v1/projects/mayuaycdtijbctgqbycg/postgrest").
+		Times(3).
+		Reply(http.StatusOK).
+		JSON(api.V1PostgrestConfigResponse{
+			DbExtraSearchPath: "public,extensions",
+			DbSchema:          "public,storage,graphql_public",
+			MaxRows:           500,
+		})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccPostgrestConfigResourceConfig(1000),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("supabase_postgrest_config.test", "id", "mayuaycdtijbctgqbycg"),
+					resource.TestCheckResourceAttr("supabase_postgrest_config.test", "max_rows", "1000"),
+					resource.TestCheckResourceAttr("supabase_postgrest_config.test", "effective.max_rows", "1000"),
+				),
+			},
+			// ImportState testing, imported settings are only effective until
+			// the configuration manages them
+			{
+				ResourceName:  "supabase_postgrest_config.test",
+				ImportState:   true,
+				ImportStateId: "mayuaycdtijbctgqbycg",
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported state, got %d", len(states))
+					}
+					if _, ok := states[0].Attributes["max_rows"]; ok {
+						return fmt.Errorf("expected max_rows to be unmanaged, got %s", states[0].Attributes["max_rows"])
+					}
+					if rows := states[0].Attributes["effective.max_rows"]; rows != "1000" {
+						return fmt.Errorf("expected effective.max_rows 1000, got %q", rows)
+					}
+					return nil
+				},
+			},
+			// Update and Read testing
+			{
+				Config: testAccPostgrestConfigResourceConfig(500),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("supabase_postgrest_config.test", "max_rows", "500"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPostgrestConfigResourceConfig(maxRows int) string {
+	return `
+resource "supabase_postgrest_config" "test" {
+  project_ref          = "mayuaycdtijbctgqbycg"
+  db_extra_search_path = "public,extensions"
+  db_schema            = "public,storage,graphql_public"
+  max_rows             = ` + strconv.Itoa(maxRows) + `
+}
+`
+}
+
+// TestConfigResourceSchemas checks that the models of the per-service
+// resources match their schemas.
+func TestConfigResourceSchemas(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		resource fwresource.Resource
+		model    any
+	}{
+		{resource: NewAuthConfigResource(), model: &settings.AuthConfigResourceModel{}},
+		{resource: NewPostgresConfigResource(), model: &settings.PostgresConfigResourceModel{}},
+		{resource: NewPostgrestConfigResource(), model: &settings.PostgrestConfigResourceModel{}},
+		{resource: NewStorageConfigResource(), model: &settings.StorageConfigResourceModel{}},
+		{resource: NewPoolerConfigResource(), model: &settings.PoolerConfigResourceModel{}},
+	}
+
+	for _, tt := range tests {
+		metadata := &fwresource.MetadataResponse{}
+		tt.resource.Metadata(ctx, fwresource.MetadataRequest{ProviderTypeName: "supabase"}, metadata)
+
+		t.Run(metadata.TypeName, func(t *testing.T) {
+			schemaResp := &fwresource.SchemaResponse{}
+			tt.resource.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+			if diags := schemaResp.Schema.ValidateImplementation(ctx); diags.HasError() {
+				t.Fatalf("invalid schema: %v", diags)
+			}
+
+			objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+			attributes := map[string]tftypes.Value{}
+			for name, attributeType := range objectType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(attributeType, nil)
+			}
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(objectType, attributes),
+			}
+			if diags := state.Get(ctx, tt.model); diags.HasError() {
+				t.Fatalf("unable to get state: %v", diags)
+			}
+			if diags := state.Set(ctx, tt.model); diags.HasError() {
+				t.Fatalf("unable to set state: %v", diags)
+			}
+		})
+	}
+}
+
+func TestConfigResourceMoveState(t *testing.T) {
+	ctx := context.Background()
+
+	sourceSchema := settings.SettingsSchema()
+	source := tfsdk.State{
+		Schema: sourceSchema,
+		Raw:    tftypes.NewValue(sourceSchema.Type().TerraformType(ctx), nil),
+	}
+	effectiveType := sourceSchema.Attributes["effective"].GetType().(types.ObjectType)
+	diags := source.Set(ctx, &settings.SettingsResourceModel{
+		ProjectRef: types.StringValue("mayuaycdtijbctgqbycg"),
+		Id:         types.StringValue("mayuaycdtijbctgqbycg"),
+		Effective:  types.ObjectNull(effectiveType.AttrTypes),
+		Database: &settings.DatabaseConfig{
+			StatementTimeout: types.StringValue("10s"),
+		},
+		Api: &settings.ApiConfig{
+			MaxRows: types.Int64Value(1000),
+		},
+	})
+	if diags.HasError() {
+		t.Fatalf("unable to set source state: %v", diags)
+	}
+
+	r := NewPostgresConfigResource()
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	move := func(typeName string) *fwresource.MoveStateResponse {
+		resp := &fwresource.MoveStateResponse{
+			TargetState: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			},
+		}
+		mover := r.(fwresource.ResourceWithMoveState).MoveState(ctx)[0]
+		mover.StateMover(ctx, fwresource.MoveStateRequest{
+			SourceTypeName:        typeName,
+			SourceProviderAddress: "registry.terraform.io/supabase/supabase",
+			SourceState:           &source,
+		}, resp)
+		return resp
+	}
+
+	resp := move("supabase_settings")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unable to move state: %v", resp.Diagnostics)
+	}
+
+	var target settings.PostgresConfigResourceModel
+	if diags := resp.TargetState.Get(ctx, &target); diags.HasError() {
+		t.Fatalf("unable to get target state: %v", diags)
+	}
+	if target.Id.ValueString() != "mayuaycdtijbctgqbycg" || target.ProjectRef.ValueString() != "mayuaycdtijbctgqbycg" {
+		t.Errorf("expected the project to move, got id %s and project_ref %s", target.Id, target.ProjectRef)
+	}
+	if target.StatementTimeout.ValueString() != "10s" {
+		t.Errorf("expected statement_timeout 10s, got %s", target.StatementTimeout)
+	}
+
+	// Other source types are skipped
+	resp = move("supabase_project")
+	if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+		t.Errorf("expected supabase_project to be skipped, got %v", resp.Diagnostics)
+	}
+}
+
+// TestConfigResourceReadManagedOnly checks that a refresh only fills the
+// settings of the prior state, and shows every setting in effective.
+func TestConfigResourceReadManagedOnly(t *testing.T) {
+	defer gock.OffAll()
+	ctx := context.Background()
+
+	gock.New("https://api.supabase.com").
+		Get("/v1/projects/mayuaycdtijbctgqbycg/postgrest").
+		Reply(http.StatusOK).
+		JSON(api.V1PostgrestConfigResponse{
+			DbExtraSearchPath: "public,extensions",
+			DbSchema:          "public,storage,graphql_public",
+			MaxRows:           500,
+		})
+
+	client, err := api.NewClientWithResponses("https://api.supabase.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewPostgrestConfigResource()
+	configureResp := &fwresource.ConfigureResponse{}
+	r.(fwresource.ResourceWithConfigure).Configure(ctx, fwresource.ConfigureRequest{
+		ProviderData: &settings.SupabaseProviderData{ManagementClient: client},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("unable to configure: %v", configureResp.Diagnostics)
+	}
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	effectiveType := schemaResp.Schema.Attributes["effective"].GetType().(types.ObjectType)
+
+	prior := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := prior.Set(ctx, &settings.PostgrestConfigResourceModel{
+		ProjectRef: types.StringValue("mayuaycdtijbctgqbycg"),
+		Id:         types.StringValue("mayuaycdtijbctgqbycg"),
+		Effective:  types.ObjectNull(effectiveType.AttrTypes),
+		ApiConfig: settings.ApiConfig{
+			MaxRows: types.Int64Value(1000),
+		},
+	})
+	if diags.HasError() {
+		t.Fatalf("unable to set prior state: %v", diags)
+	}
+
+	resp := &fwresource.ReadResponse{State: prior}
+	r.Read(ctx, fwresource.ReadRequest{State: prior}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unable to read: %v", resp.Diagnostics)
+	}
+
+	var state settings.PostgrestConfigResourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("unable to get state: %v", diags)
+	}
+	if state.MaxRows.ValueInt64() != 500 {
+		t.Errorf("expected max_rows to be refreshed to 500, got %s", state.MaxRows)
+	}
+	if !state.DbSchema.IsNull() || !state.DbExtraSearchPath.IsNull() {
+		t.Errorf("expected unmanaged settings to stay null, got db_schema %s and db_extra_search_path %s", state.DbSchema, state.DbExtraSearchPath)
+	}
+
+	effective := state.Effective.Attributes()
+	if got := effective["db_schema"]; !got.Equal(types.StringValue("public,storage,graphql_public")) {
+		t.Errorf("expected effective db_schema, got %s", got)
+	}
+	if got := effective["max_rows"]; !got.Equal(types.Int64Value(500)) {
+		t.Errorf("expected effective max_rows 500, got %s", got)
+	}
+}
+
+// TestConfigResourceResetOnDestroy checks that the split resources restore
+// the defaults of removed settings, and of every setting on destroy.
+func TestConfigResourceResetOnDestroy(t *testing.T) {
+	defer gock.OffAll()
+	ctx := context.Background()
+
+	client, err := api.NewClientWithResponses("https://api.supabase.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewPostgresConfigResource()
+	configureResp := &fwresource.ConfigureResponse{}
+	r.(fwresource.ResourceWithConfigure).Configure(ctx, fwresource.ConfigureRequest{
+		ProviderData: &settings.SupabaseProviderData{ManagementClient: client},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("unable to configure: %v", configureResp.Diagnostics)
+	}
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	effectiveType := schemaResp.Schema.Attributes["effective"].GetType().(types.ObjectType)
+
+	newState := func(database settings.DatabaseConfig) tfsdk.State {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		diags := state.Set(ctx, &settings.PostgresConfigResourceModel{
+			ProjectRef:     types.StringValue("mayuaycdtijbctgqbycg"),
+			Id:             types.StringValue("mayuaycdtijbctgqbycg"),
+			Effective:      types.ObjectNull(effectiveType.AttrTypes),
+			ResetOnDestroy: types.BoolValue(true),
+			DatabaseConfig: database,
+		})
+		if diags.HasError() {
+			t.Fatalf("unable to set state: %v", diags)
+		}
+		return state
+	}
+
+	prior := newState(settings.DatabaseConfig{
+		StatementTimeout:       types.StringValue("30s"),
+		LogicalDecodingWorkMem: types.StringValue("128MB"),
+		MaxConnections:         types.Int64Value(200),
+	})
+
+	// Removing statement_timeout restores its default after the update
+	gock.New("https://api.supabase.com").
+		Put("/v1/projects/mayuaycdtijbctgqbycg/config/database/postgres").
+		MatchType("json").
+		JSON(map[string]any{"logical_decoding_work_mem": "128MB", "max_connections": 200}).
+		Reply(http.StatusOK).
+		JSON(map[string]any{"logical_decoding_work_mem": "128MB", "max_connections": 200})
+	gock.New("https://api.supabase.com").
+		Put("/v1/projects/mayuaycdtijbctgqbycg/config/database/postgres").
+		MatchType("json").
+		JSON(map[string]any{"statement_timeout": "2min"}).
+		Reply(http.StatusOK).
+		JSON(map[string]any{"statement_timeout": "2min"})
+
+	plan := newState(settings.DatabaseConfig{
+		LogicalDecodingWorkMem: types.StringValue("128MB"),
+		MaxConnections:         types.Int64Value(200),
+	})
+	updateResp := &fwresource.UpdateResponse{State: prior}
+	r.Update(ctx, fwresource.UpdateRequest{
+		State:  prior,
+		Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+	}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unable to update: %v", updateResp.Diagnostics)
+	}
+	if !gock.IsDone() {
+		t.Errorf("expected statement_timeout to be reset")
+	}
+
+	// Destroying restores every default, and keeps max_connections
+	gock.New("https://api.supabase.com").
+		Put("/v1/projects/mayuaycdtijbctgqbycg/config/database/postgres").
+		MatchType("json").
+		JSON(map[string]any{"logical_decoding_work_mem": "64MB", "statement_timeout": "2min"}).
+		Reply(http.StatusOK).
+		JSON(map[string]any{"logical_decoding_work_mem": "64MB", "statement_timeout": "2min"})
+
+	deleteResp := &fwresource.DeleteResponse{State: prior}
+	r.Delete(ctx, fwresource.DeleteRequest{State: prior}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unable to delete: %v", deleteResp.Diagnostics)
+	}
+	if !gock.IsDone() {
+		t.Errorf("expected logical_decoding_work_mem and statement_timeout to be reset")
+	}
+
+	warnings := deleteResp.Diagnostics.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Detail(), "database.max_connections") {
+		t.Errorf("expected a warning for database.max_connections, got %v", deleteResp.Diagnostics)
+	}
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Connection modes of connection_string
+const (
+	connectionModeDirect      = "direct"
+	connectionModeSession     = "session"
+	connectionModeTransaction = "transaction"
+)
+
+// poolerDomain is the domain of the shared Supavisor poolers
+const poolerDomain = "pooler.supabase.com"
+
+// poolerRegionPattern matches a region prefixed with its pooler cluster, such
+// as aws-1-eu-central-1. Projects of a region are spread across clusters, so
+// the cluster cannot be derived from the region.
+var poolerRegionPattern = regexp.MustCompile(`^aws-[0-9]+-[a-z]+(-[a-z]+)*-[0-9]+$`)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ConnectionStringFunction{}
+
+func NewConnectionStringFunction() function.Function {
+	return &ConnectionStringFunction{}
+}
+
+// ConnectionStringFunction builds Postgres connection URIs of a project.
+type ConnectionStringFunction struct{}
+
+func (f *ConnectionStringFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "connection_string"
+}
+
+func (f *ConnectionStringFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build a Postgres connection URI of a project",
+		MarkdownDescription: "Builds the Postgres connection URI of a project for a direct connection, or through the session or transaction mode of the shared pooler. " +
+			"Pooler connections log in as `<user>.<ref>` on port `5432` in session mode and `6543` in transaction mode. " +
+			"The password is escaped, so it can contain any character.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "ref",
+				MarkdownDescription: "Project reference",
+			},
+			function.StringParameter{
+				Name:                "user",
+				MarkdownDescription: "Database user, usually `postgres`",
+			},
+			function.StringParameter{
+				Name:                "password",
+				MarkdownDescription: "Database password",
+			},
+			function.StringParameter{
+				Name:                "mode",
+				MarkdownDescription: "One of `direct`, `session`, or `transaction`",
+				Validators: []function.StringParameterValidator{
+					stringvalidator.OneOf(connectionModeDirect, connectionModeSession, connectionModeTransaction),
+				},
+			},
+			function.StringParameter{
+				Name:                "region",
+				MarkdownDescription: "Region of the project prefixed with its pooler cluster, in the form `aws-N-<region>` such as `aws-0-us-east-1`, for pooler connections. The pooler host in the connection settings of the project shows it. Ignored for direct connections, and can be null.",
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ConnectionStringFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ref, user, password, mode string
+	var region types.String
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &ref, &user, &password, &mode, &region))
+	if resp.Error != nil {
+		return
+	}
+
+	uri, err := connectionString(ref, user, password, mode, region.ValueString())
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, uri))
+}
+
+// connectionString returns the Postgres connection URI of a project in the
+// given connection mode.
+func connectionString(ref string, user string, password string, mode string, region string) (string, error) {
+	if !projectRefPattern.MatchString(ref) {
+		return "", fmt.Errorf("%q is not a project reference", ref)
+	}
+
+	uri := url.URL{
+		Scheme: "postgresql",
+		Path:   "/postgres",
+	}
+
+	switch mode {
+	case connectionModeDirect:
+		uri.User = url.UserPassword(user, password)
+		uri.Host = fmt.Sprintf("db.%s.supabase.co:5432", ref)
+	case connectionModeSession, connectionModeTransaction:
+		host, err := poolerHost(region)
+		if err != nil {
+			return "", err
+		}
+		port := 5432
+		if mode == connectionModeTransaction {
+			port = 6543
+		}
+		uri.User = url.UserPassword(user+"."+ref, password)
+		uri.Host = fmt.Sprintf("%s:%d", host, port)
+	default:
+		return "", fmt.Errorf("unknown connection mode %q, expected direct, session, or transaction", mode)
+	}
+
+	return uri.String(), nil
+}
+
+// poolerHost returns the hostname of the shared pooler of a region prefixed
+// with its pooler cluster.
+func poolerHost(region string) (string, error) {
+	if region == "" {
+		return "", fmt.Errorf("region is required for pooler connections, in the form aws-N-<region> such as aws-0-us-east-1")
+	}
+
+	if !poolerRegionPattern.MatchString(region) {
+		return "", fmt.Errorf("region %q must name its pooler cluster, in the form aws-N-<region> such as aws-0-us-east-1", region)
+	}
+	return region + "." + poolerDomain, nil
+}
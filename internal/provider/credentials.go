@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	// Keyring service and account used by `supabase login`
+	cliKeyringService = "Supabase CLI"
+	cliKeyringAccount = "access-token"
+
+	defaultProfile = "default"
+)
+
+// errNoCredentials is returned when no access token could be discovered
+var errNoCredentials = errors.New("no Supabase access token found")
+
+// providerProfile is a named set of provider settings in the profiles file
+type providerProfile struct {
+	AccessToken string `toml:"access_token"`
+	Endpoint    string `toml:"endpoint"`
+}
+
+// discoveredCredentials describes the access token the provider runs with,
+// and where it was found.
+type discoveredCredentials struct {
+	AccessToken string
+	Endpoint    string
+	Source      string
+}
+
+// keyringGet reads a secret from the native credential store, and can be
+// replaced in tests.
+var keyringGet = readKeyring
+
+// discoverCredentials looks up an access token in the same places as the
+// Supabase CLI, after the profiles file:
+//
+//  1. the profile named profile in profilesFile, or its default profile
+//  2. the native credential store written by `supabase login`
+//  3. ~/.supabase/access-token, the fallback of `supabase login`
+//
+// An explicitly requested profile must exist.
+func discoverCredentials(profile string, profilesFile string) (*discoveredCredentials, error) {
+	profiles, err := loadProfiles(profilesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	name := profile
+	if name == "" {
+		name = defaultProfile
+	}
+	if selected, ok := profiles[name]; ok {
+		if selected.AccessToken == "" {
+			return nil, fmt.Errorf("profile %q in %s has no access_token", name, profilesFile)
+		}
+		return &discoveredCredentials{
+			AccessToken: selected.AccessToken,
+			Endpoint:    selected.Endpoint,
+			Source:      fmt.Sprintf("profile %q in %s", name, profilesFile),
+		}, nil
+	} else if profile != "" {
+		return nil, fmt.Errorf("profile %q not found in %s", profile, profilesFile)
+	}
+
+	if token, err := keyringGet(cliKeyringService, cliKeyringAccount); err == nil && token != "" {
+		return &discoveredCredentials{AccessToken: token, Source: "Supabase CLI credential store"}, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, errNoCredentials
+	}
+	tokenPath := filepath.Join(home, ".supabase", "access-token")
+	if content, err := os.ReadFile(tokenPath); err == nil {
+		if token := strings.TrimSpace(string(content)); token != "" {
+			return &discoveredCredentials{AccessToken: token, Source: tokenPath}, nil
+		}
+	}
+
+	return nil, errNoCredentials
+}
+
+// defaultProfilesFile returns the location of the profiles file,
+// ~/.supabase/profiles.toml.
+func defaultProfilesFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".supabase", "profiles.toml")
+}
+
+// loadProfiles reads the profiles file. A missing file has no profiles.
+func loadProfiles(profilesFile string) (map[string]providerProfile, error) {
+	profiles := map[string]providerProfile{}
+	if profilesFile == "" {
+		return profiles, nil
+	}
+
+	if _, err := toml.DecodeFile(profilesFile, &profiles); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return profiles, nil
+		}
+		return nil, fmt.Errorf("failed to read profiles from %s: %w", profilesFile, err)
+	}
+
+	return profiles, nil
+}
+
+// readKeyring reads a secret the way github.com/zalando/go-keyring stores
+// it, which is what the Supabase CLI uses, through the system tools.
+func readKeyring(service string, account string) (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		out, err := exec.Command("/usr/bin/security", "find-generic-password", "-s", service, "-a", account, "-w").Output()
+		if err != nil {
+			return "", err
+		}
+		return decodeKeyringSecret(strings.TrimSpace(string(out)))
+	case "linux":
+		out, err := exec.Command("secret-tool", "lookup", "service", service, "username", account).Output()
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(out)), nil
+	default:
+		return "", fmt.Errorf("reading the credential store is not supported on %s", runtime.GOOS)
+	}
+}
+
+// decodeKeyringSecret undoes the encoding go-keyring applies on macOS.
+func decodeKeyringSecret(secret string) (string, error) {
+	if encoded, ok := strings.CutPrefix(secret, "go-keyring-base64:"); ok {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		return string(decoded), err
+	}
+	if encoded, ok := strings.CutPrefix(secret, "go-keyring-encoded:"); ok {
+		decoded, err := hex.DecodeString(encoded)
+		return string(decoded), err
+	}
+	return secret, nil
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func stubKeyring(t *testing.T, secret string) {
+	previous := keyringGet
+	keyringGet = func(service string, account string) (string, error) {
+		if service != cliKeyringService || account != cliKeyringAccount {
+			t.Errorf("Unexpected keyring lookup %s/%s", service, account)
+		}
+		if secret == "" {
+			return "", errors.New("not found")
+		}
+		return secret, nil
+	}
+	t.Cleanup(func() { keyringGet = previous })
+}
+
+func TestDiscoverCredentialsProfiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	stubKeyring(t, "sbp_keyring")
+
+	profilesFile := filepath.Join(t.TempDir(), "profiles.toml")
+	profiles := `
+[default]
+access_token = "sbp_default"
+
+[staging]
+access_token = "sbp_staging"
+endpoint = "https://api.supabase.green"
+`
+	if err := os.WriteFile(profilesFile, []byte(profiles), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	credentials, err := discoverCredentials("", profilesFile)
+	if err != nil || credentials.AccessToken != "sbp_default" {
+		t.Fatalf("Expected default profile, got %+v %v", credentials, err)
+	}
+
+	credentials, err = discoverCredentials("staging", profilesFile)
+	if err != nil || credentials.AccessToken != "sbp_staging" || credentials.Endpoint != "https://api.supabase.green" {
+		t.Fatalf("Expected staging profile, got %+v %v", credentials, err)
+	}
+
+	if _, err := discoverCredentials("production", profilesFile); err == nil || !strings.Contains(err.Error(), `"production" not found`) {
+		t.Errorf("Expected missing profile error, got %v", err)
+	}
+}
+
+func TestDiscoverCredentialsCLILogin(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	missingProfiles := filepath.Join(home, "missing.toml")
+
+	// The credential store takes precedence over the token file
+	stubKeyring(t, "sbp_keyring")
+	credentials, err := discoverCredentials("", missingProfiles)
+	if err != nil || credentials.AccessToken != "sbp_keyring" {
+		t.Fatalf("Expected keyring token, got %+v %v", credentials, err)
+	}
+
+	stubKeyring(t, "")
+	if _, err := discoverCredentials("", missingProfiles); !errors.Is(err, errNoCredentials) {
+		t.Errorf("Expected no credentials error, got %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(home, ".supabase"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".supabase", "access-token"), []byte("sbp_file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	credentials, err = discoverCredentials("", missingProfiles)
+	if err != nil || credentials.AccessToken != "sbp_file" {
+		t.Fatalf("Expected token file, got %+v %v", credentials, err)
+	}
+}
+
+func TestDecodeKeyringSecret(t *testing.T) {
+	cases := map[string]string{
+		"sbp_plain":                          "sbp_plain",
+		"go-keyring-base64:c2JwX2Jhc2U2NA==": "sbp_base64",
+		"go-keyring-encoded:7362705f686578":  "sbp_hex",
+	}
+
+	for secret, expected := range cases {
+		if actual, err := decodeKeyringSecret(secret); err != nil || actual != expected {
+			t.Errorf("Expected %s from %s, got %s %v", expected, secret, actual, err)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
+	"github.com/supabase/cli/pkg/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &DatabaseCredentialsEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &DatabaseCredentialsEphemeralResource{}
+)
+
+func NewDatabaseCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &DatabaseCredentialsEphemeralResource{}
+}
+
+// DatabaseCredentialsEphemeralResource returns the database credentials of a
+// branch without persisting them in state or plan.
+type DatabaseCredentialsEphemeralResource struct {
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
+}
+
+type DatabaseCredentialsEphemeralResourceModel struct {
+	BranchId   types.String `tfsdk:"branch_id"`
+	ProjectRef types.String `tfsdk:"project_ref"`
+	Host       types.String `tfsdk:"host"`
+	Port       types.Int64  `tfsdk:"port"`
+	User       types.String `tfsdk:"user"`
+	Password   types.String `tfsdk:"password"`
+	JwtSecret  types.String `tfsdk:"jwt_secret"`
+}
+
+func (r *DatabaseCredentialsEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database_credentials"
+}
+
+func (r *DatabaseCredentialsEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Returns the database credentials of a branch, like the ` + "`database`" + ` attribute of ` + "`supabase_branch`" + `, without persisting them in the state or plan. Requires Terraform 1.10 or later.
+
+## Example Usage
+
+~~~hcl
+ephemeral "supabase_database_credentials" "preview" {
+  branch_id = supabase_branch.preview.id
+}
+
+provider "postgresql" {
+  host     = ephemeral.supabase_database_credentials.preview.host
+  port     = ephemeral.supabase_database_credentials.preview.port
+  username = ephemeral.supabase_database_credentials.preview.user
+  password = ephemeral.supabase_database_credentials.preview.password
+}
+~~~
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"branch_id": schema.StringAttribute{
+				MarkdownDescription: "Branch identifier",
+				Required:            true,
+			},
+			"project_ref": schema.StringAttribute{
+				MarkdownDescription: "Project reference of the branch",
+				Computed:            true,
+			},
+			"host": schema.StringAttribute{
+				MarkdownDescription: "Database host",
+				Computed:            true,
+			},
+			"port": schema.Int64Attribute{
+				MarkdownDescription: "Database port",
+				Computed:            true,
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "Database user",
+				Computed:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Database password",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"jwt_secret": schema.StringAttribute{
+				MarkdownDescription: "JWT secret",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (r *DatabaseCredentialsEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*settings.SupabaseProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *settings.SupabaseProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(providerData.RequireManagementAPI("supabase_database_credentials")...)
+	r.client = providerData.ManagementClient
+	r.providerData = providerData
+}
+
+func (r *DatabaseCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	// Skipped ephemeral resources return their configuration
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
+	var data DatabaseCredentialsEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.V1GetABranchConfigWithResponse(ctx, data.BranchId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read database credentials, got error: %s", err))
+		return
+	}
+	if httpResp.JSON200 == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read database credentials, got status %d: %s", httpResp.StatusCode(), httpResp.Body))
+		return
+	}
+
+	data.ProjectRef = types.StringValue(httpResp.JSON200.Ref)
+	data.Host = types.StringValue(httpResp.JSON200.DbHost)
+	data.Port = types.Int64Value(int64(httpResp.JSON200.DbPort))
+	data.User = types.StringPointerValue(httpResp.JSON200.DbUser)
+	data.Password = types.StringPointerValue(httpResp.JSON200.DbPass)
+	data.JwtSecret = types.StringPointerValue(httpResp.JSON200.JwtSecret)
+
+	tflog.Trace(ctx, "opened database credentials")
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccDatabaseCredentialsEphemeralResource(t *testing.T) {
+	// Setup mock api
+	defer gock.OffAll()
+	gock.New("https://api.supabase.com").
+		Get("/v1/branches/test-branch").
+		Persist().
+		Reply(http.StatusOK).
+		JSON(api.BranchDetailResponse{
+			Ref:    "abcdefghijklmnopqrst",
+			DbHost: "db.abcdefghijklmnopqrst.supabase.co",
+			DbPort: 5432,
+			DbUser: Ptr("postgres"),
+			DbPass: Ptr("branch-password"),
+		})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+ephemeral "supabase_database_credentials" "preview" {
+  branch_id = "test-branch"
+}
+
+provider "echo" {
+  data = ephemeral.supabase_database_credentials.preview
+}
+
+resource "echo" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.project_ref", "abcdefghijklmnopqrst"),
+					resource.TestCheckResourceAttr("echo.test", "data.host", "db.abcdefghijklmnopqrst.supabase.co"),
+					resource.TestCheckResourceAttr("echo.test", "data.port", "5432"),
+					resource.TestCheckResourceAttr("echo.test", "data.user", "postgres"),
+					resource.TestCheckResourceAttr("echo.test", "data.password", "branch-password"),
+				),
+			},
+		},
+	})
+}
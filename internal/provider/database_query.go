@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
+	"github.com/supabase/cli/pkg/api"
+)
+
+// runDatabaseQuery runs SQL against the project database, through the
+// Management API or through pg-meta on the local stack in local mode. When out
+// is non-nil, the resulting rows are decoded into it.
+func runDatabaseQuery(ctx context.Context, providerData *settings.SupabaseProviderData, projectRef string, query string, out any) diag.Diagnostics {
+	if providerData.Mode == settings.ModeLocal {
+		return runLocalDatabaseQuery(ctx, providerData, query, out)
+	}
+
+	// The generated client expects an object, but queries return an array
+	// of rows, so the request is made directly.
+	httpResp, err := managementRequest(ctx, providerData.ManagementClient, http.MethodPost, fmt.Sprintf("/v1/projects/%s/database/query", projectRef), api.V1RunQueryBody{Query: query}, out)
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to run query, got error: %s", err))}
+	}
+
+	if httpResp.StatusCode != http.StatusCreated && httpResp.StatusCode != http.StatusOK {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("API Error", fmt.Sprintf("Unable to run query, got status %d: %s", httpResp.StatusCode, httpResp.Body))}
+	}
+
+	return nil
+}
+
+// runLocalDatabaseQuery runs SQL through the pg-meta service that the local
+// API gateway exposes under /pg, authenticated with the service role key.
+func runLocalDatabaseQuery(ctx context.Context, providerData *settings.SupabaseProviderData, query string, out any) diag.Diagnostics {
+	encoded, err := json.Marshal(api.V1RunQueryBody{Query: query})
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to encode query: %s", err))}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, providerData.LocalAPIURL+"/pg/query", bytes.NewReader(encoded))
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to run query, got error: %s", err))}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("apikey", providerData.ServiceRoleKey)
+	req.Header.Set("Authorization", "Bearer "+providerData.ServiceRoleKey)
+
+	httpClient := http.DefaultClient
+	if providerData.HTTPClient != nil {
+		httpClient = providerData.HTTPClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to run query, got error: %s", err))}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to read query result: %s", err))}
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("API Error", fmt.Sprintf("Unable to run query, got status %d: %s", resp.StatusCode, body))}
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(body, out); err != nil {
+			return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to decode query result: %s", err))}
+		}
+	}
+
+	return nil
+}
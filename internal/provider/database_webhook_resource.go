@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -15,13 +16,15 @@ import (
 )
 
 var _ resource.Resource = &DatabaseWebhookResource{}
+var _ resource.ResourceWithModifyPlan = &DatabaseWebhookResource{}
 
 func NewDatabaseWebhookResource() resource.Resource {
 	return &DatabaseWebhookResource{}
 }
 
 type DatabaseWebhookResource struct {
-	client *api.ClientWithResponses
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
 }
 
 type DatabaseWebhookResourceModel struct {
@@ -39,9 +42,11 @@ func (r *DatabaseWebhookResource) Schema(ctx context.Context, req resource.Schem
 		MarkdownDescription: "Database Webhook resource for enabling database webhooks (Beta feature)",
 		Attributes: map[string]schema.Attribute{
 			"project_ref": schema.StringAttribute{
-				MarkdownDescription: "Project reference",
-				Required:            true,
+				MarkdownDescription: "Project reference. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -75,6 +80,12 @@ func (r *DatabaseWebhookResource) Configure(ctx context.Context, req resource.Co
 	}
 
 	r.client = providerData.ManagementClient
+	r.providerData = providerData
+}
+
+// ModifyPlan falls back to the provider project_ref when the resource omits it.
+func (r *DatabaseWebhookResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.PlanProjectRef(ctx, req, resp)
 }
 
 func (r *DatabaseWebhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -86,14 +97,8 @@ func (r *DatabaseWebhookResource) Create(ctx context.Context, req resource.Creat
 	}
 
 	if data.Enabled.ValueBool() {
-		httpResp, err := r.client.V1EnableDatabaseWebhookWithResponse(ctx, data.ProjectRef.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to enable database webhook: %s", err))
-			return
-		}
-
-		if httpResp.StatusCode() != http.StatusCreated {
-			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to enable database webhook, got status %d: %s", httpResp.StatusCode(), httpResp.Body))
+		resp.Diagnostics.Append(r.enable(ctx, data.ProjectRef.ValueString())...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
@@ -124,14 +129,8 @@ func (r *DatabaseWebhookResource) Update(ctx context.Context, req resource.Updat
 	}
 
 	if data.Enabled.ValueBool() {
-		httpResp, err := r.client.V1EnableDatabaseWebhookWithResponse(ctx, data.ProjectRef.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to enable database webhook: %s", err))
-			return
-		}
-
-		if httpResp.StatusCode() != http.StatusCreated && httpResp.StatusCode() != http.StatusOK {
-			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to enable database webhook, got status %d: %s", httpResp.StatusCode(), httpResp.Body))
+		resp.Diagnostics.Append(r.enable(ctx, data.ProjectRef.ValueString())...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 	} else {
@@ -153,4 +152,37 @@ func (r *DatabaseWebhookResource) Delete(ctx context.Context, req resource.Delet
 	// Note: The API doesn't provide a way to disable webhooks
 	// The resource will be removed from Terraform state but the webhook remains enabled in Supabase
 	resp.Diagnostics.AddWarning("Webhook Disable", "The Supabase API does not provide a way to disable database webhooks. The webhook will remain enabled in Supabase even though it's removed from Terraform state.")
-}
\ No newline at end of file
+}
+
+// localWebhooksQuery checks that the schema of database webhooks exists.
+const localWebhooksQuery = `SELECT EXISTS (SELECT 1 FROM pg_namespace WHERE nspname = 'supabase_functions') AS installed`
+
+// enable enables the database webhooks of a project. The local stack installs
+// them along with the database, so in local mode they are only checked
+// through pg-meta.
+func (r *DatabaseWebhookResource) enable(ctx context.Context, projectRef string) diag.Diagnostics {
+	if r.providerData.SkipsManagementAPI() {
+		var rows []struct {
+			Installed bool `json:"installed"`
+		}
+		diags := runDatabaseQuery(ctx, r.providerData, projectRef, localWebhooksQuery, &rows)
+		if diags.HasError() {
+			return diags
+		}
+		if len(rows) == 0 || !rows[0].Installed {
+			return diag.Diagnostics{diag.NewErrorDiagnostic("Database Webhooks Unavailable", "The local database has no supabase_functions schema. Restart the local stack with `supabase start` to install database webhooks.")}
+		}
+		return nil
+	}
+
+	httpResp, err := r.client.V1EnableDatabaseWebhookWithResponse(ctx, projectRef)
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to enable database webhook: %s", err))}
+	}
+
+	if httpResp.StatusCode() != http.StatusCreated && httpResp.StatusCode() != http.StatusOK {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("API Error", fmt.Sprintf("Unable to enable database webhook, got status %d: %s", httpResp.StatusCode(), httpResp.Body))}
+	}
+
+	return nil
+}
@@ -18,8 +18,9 @@ import (
 )
 
 var (
-	_ resource.Resource              = &EdgeFunctionResource{}
-	_ resource.ResourceWithConfigure = &EdgeFunctionResource{}
+	_ resource.Resource               = &EdgeFunctionResource{}
+	_ resource.ResourceWithConfigure  = &EdgeFunctionResource{}
+	_ resource.ResourceWithModifyPlan = &EdgeFunctionResource{}
 )
 
 func NewEdgeFunctionResource() resource.Resource {
@@ -27,8 +28,9 @@ func NewEdgeFunctionResource() resource.Resource {
 }
 
 type EdgeFunctionResource struct {
-	client  *api.ClientWithResponses
-	tempDir string
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
+	tempDir      string
 }
 
 
@@ -74,9 +76,11 @@ resource "supabase_edge_function" "example" {
 `,
 		Attributes: map[string]schema.Attribute{
 			"project_ref": schema.StringAttribute{
-				MarkdownDescription: "Project reference ID",
-				Required:            true,
+				MarkdownDescription: "Project reference ID. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -147,11 +151,23 @@ func (r *EdgeFunctionResource) Configure(ctx context.Context, req resource.Confi
 		return
 	}
 
+	resp.Diagnostics.Append(providerData.RequireManagementAPI("supabase_edge_function")...)
 	r.client = providerData.ManagementClient
+	r.providerData = providerData
 	r.tempDir = os.TempDir()
 }
 
+// ModifyPlan falls back to the provider project_ref when the resource omits it.
+func (r *EdgeFunctionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.PlanProjectRef(ctx, req, resp)
+}
+
 func (r *EdgeFunctionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(settings.SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
 	var data EdgeFunctionResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -192,6 +208,11 @@ func (r *EdgeFunctionResource) Create(ctx context.Context, req resource.CreateRe
 }
 
 func (r *EdgeFunctionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Skipped resources keep their prior state
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
 	var data EdgeFunctionResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -246,6 +267,11 @@ func (r *EdgeFunctionResource) Read(ctx context.Context, req resource.ReadReques
 }
 
 func (r *EdgeFunctionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(settings.SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
 	var data EdgeFunctionResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -281,6 +307,10 @@ func (r *EdgeFunctionResource) Update(ctx context.Context, req resource.UpdateRe
 }
 
 func (r *EdgeFunctionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
 	var data EdgeFunctionResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -0,0 +1,419 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
+	"github.com/supabase/cli/pkg/api"
+)
+
+var (
+	_ resource.Resource                = &JwtSigningKeyResource{}
+	_ resource.ResourceWithConfigure   = &JwtSigningKeyResource{}
+	_ resource.ResourceWithImportState = &JwtSigningKeyResource{}
+	_ resource.ResourceWithModifyPlan  = &JwtSigningKeyResource{}
+)
+
+const (
+	jwtSigningKeyStatusInUse          = "in_use"
+	jwtSigningKeyStatusStandby        = "standby"
+	jwtSigningKeyStatusPreviouslyUsed = "previously_used"
+	jwtSigningKeyStatusRevoked        = "revoked"
+)
+
+func NewJwtSigningKeyResource() resource.Resource {
+	return &JwtSigningKeyResource{}
+}
+
+// JwtSigningKeyResource manages an asymmetric JWT signing key of a project.
+type JwtSigningKeyResource struct {
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
+}
+
+type JwtSigningKeyResourceModel struct {
+	ProjectRef types.String `tfsdk:"project_ref"`
+	Id         types.String `tfsdk:"id"`
+	Algorithm  types.String `tfsdk:"algorithm"`
+	Status     types.String `tfsdk:"status"`
+	PrivateJwk types.String `tfsdk:"private_jwk"`
+	PublicJwk  types.String `tfsdk:"public_jwk"`
+	CreatedAt  types.String `tfsdk:"created_at"`
+	UpdatedAt  types.String `tfsdk:"updated_at"`
+}
+
+// jwtSigningKey mirrors the signing key payload of the Management API, which
+// the generated client does not cover yet.
+type jwtSigningKey struct {
+	Id        string          `json:"id"`
+	Algorithm string          `json:"algorithm"`
+	Status    string          `json:"status"`
+	PublicJwk json.RawMessage `json:"public_jwk,omitempty"`
+	CreatedAt string          `json:"created_at"`
+	UpdatedAt string          `json:"updated_at"`
+}
+
+type jwtSigningKeyList struct {
+	Keys []jwtSigningKey `json:"keys"`
+}
+
+type jwtSigningKeyCreateBody struct {
+	Algorithm  string          `json:"algorithm"`
+	Status     string          `json:"status,omitempty"`
+	PrivateJwk json.RawMessage `json:"private_jwk,omitempty"`
+}
+
+type jwtSigningKeyUpdateBody struct {
+	Status string `json:"status"`
+}
+
+func (r *JwtSigningKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwt_signing_key"
+}
+
+func (r *JwtSigningKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+
+Manages a JWT signing key of a Supabase project.
+
+New keys are created in ` + "`standby`" + `. Rotating a key means setting its ` + "`status`" + ` to ` + "`in_use`" + `, which moves the key that was in use to ` + "`previously_used`" + `. Update the previous key's ` + "`status`" + ` to match, and to ` + "`revoked`" + ` once tokens signed with it have expired. Each step is a separate, reviewable apply.
+
+Refer to the [Supabase JWT signing keys documentation](https://supabase.com/docs/guides/auth/signing-keys) for more information.
+
+## Example Usage
+
+~~~hcl
+resource "supabase_jwt_signing_key" "next" {
+  project_ref = "abcdefghijklmnopqrst"
+  algorithm   = "ES256"
+  status      = "standby"
+}
+~~~
+`,
+		Attributes: map[string]schema.Attribute{
+			"project_ref": schema.StringAttribute{
+				MarkdownDescription: "Project reference ID. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Signing key ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Asymmetric signing algorithm whose public key is published through JWKS (`RS256` or `ES256`)",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("RS256", "ES256"),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Key status (`standby`, `in_use`, `previously_used`, or `revoked`). Defaults to `standby`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						jwtSigningKeyStatusStandby,
+						jwtSigningKeyStatusInUse,
+						jwtSigningKeyStatusPreviouslyUsed,
+						jwtSigningKeyStatusRevoked,
+					),
+				},
+			},
+			"private_jwk": schema.StringAttribute{
+				MarkdownDescription: "Existing private key to import, as a JSON encoded JWK. A new key is generated when omitted.",
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"public_jwk": schema.StringAttribute{
+				MarkdownDescription: "Public key as a JSON encoded JWK",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "When the signing key was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "When the signing key was last updated",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *JwtSigningKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*settings.SupabaseProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *settings.SupabaseProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(providerData.RequireManagementAPI("supabase_jwt_signing_key")...)
+	r.client = providerData.ManagementClient
+	r.providerData = providerData
+}
+
+// ModifyPlan falls back to the provider project_ref when the resource omits it.
+func (r *JwtSigningKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.PlanProjectRef(ctx, req, resp)
+}
+
+func (r *JwtSigningKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(settings.SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	var data JwtSigningKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keys are always created in standby so that promoting a key to in_use
+	// goes through the same rotation path as an update.
+	body := jwtSigningKeyCreateBody{
+		Algorithm: data.Algorithm.ValueString(),
+		Status:    jwtSigningKeyStatusStandby,
+	}
+	if !data.PrivateJwk.IsNull() {
+		if !json.Valid([]byte(data.PrivateJwk.ValueString())) {
+			resp.Diagnostics.AddAttributeError(path.Root("private_jwk"), "Invalid Private JWK", "private_jwk must be a valid JSON encoded JWK")
+			return
+		}
+		body.PrivateJwk = json.RawMessage(data.PrivateJwk.ValueString())
+	}
+
+	var key jwtSigningKey
+	httpResp, err := managementRequest(ctx, r.client, http.MethodPost, jwtSigningKeysPath(data.ProjectRef.ValueString()), body, &key)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create JWT signing key: %s", err))
+		return
+	}
+
+	if httpResp.StatusCode != http.StatusCreated && httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create JWT signing key, got status %d: %s", httpResp.StatusCode, httpResp.Body))
+		return
+	}
+
+	desired := data.Status.ValueString()
+	updateDataFromJwtSigningKey(&data, &key)
+
+	if desired != "" && desired != key.Status {
+		resp.Diagnostics.Append(r.setStatus(ctx, &data, desired)...)
+		if resp.Diagnostics.HasError() {
+			// The key exists, so keep it in state to avoid orphaning it.
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "created JWT signing key")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *JwtSigningKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Skipped resources keep their prior state
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
+	var data JwtSigningKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var key jwtSigningKey
+	httpResp, err := managementRequest(ctx, r.client, http.MethodGet, jwtSigningKeyPath(data.ProjectRef.ValueString(), data.Id.ValueString()), nil, &key)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read JWT signing key: %s", err))
+		return
+	}
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to read JWT signing key, got status %d: %s", httpResp.StatusCode, httpResp.Body))
+		return
+	}
+
+	updateDataFromJwtSigningKey(&data, &key)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *JwtSigningKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(settings.SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	var data JwtSigningKeyResourceModel
+	var state JwtSigningKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = state.Id
+	data.PublicJwk = state.PublicJwk
+	data.CreatedAt = state.CreatedAt
+	data.UpdatedAt = state.UpdatedAt
+
+	if !data.Status.IsUnknown() && data.Status.ValueString() != state.Status.ValueString() {
+		desired := data.Status.ValueString()
+		data.Status = state.Status
+		resp.Diagnostics.Append(r.setStatus(ctx, &data, desired)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		data.Status = state.Status
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *JwtSigningKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
+	var data JwtSigningKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch data.Status.ValueString() {
+	case jwtSigningKeyStatusInUse:
+		resp.Diagnostics.AddError(
+			"Signing Key In Use",
+			fmt.Sprintf("JWT signing key %s is in use and cannot be deleted. Rotate another key to in_use first.", data.Id.ValueString()),
+		)
+		return
+	case jwtSigningKeyStatusPreviouslyUsed:
+		// Previously used keys still verify tokens, so they must be revoked
+		// before the API allows removing them.
+		resp.Diagnostics.Append(r.setStatus(ctx, &data, jwtSigningKeyStatusRevoked)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	httpResp, err := managementRequest(ctx, r.client, http.MethodDelete, jwtSigningKeyPath(data.ProjectRef.ValueString(), data.Id.ValueString()), nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete JWT signing key: %s", err))
+		return
+	}
+
+	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusNotFound {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete JWT signing key, got status %d: %s", httpResp.StatusCode, httpResp.Body))
+		return
+	}
+}
+
+func (r *JwtSigningKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the format project_ref/id, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_ref"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+func (r *JwtSigningKeyResource) setStatus(ctx context.Context, data *JwtSigningKeyResourceModel, status string) diag.Diagnostics {
+	var key jwtSigningKey
+	body := jwtSigningKeyUpdateBody{Status: status}
+
+	httpResp, err := managementRequest(ctx, r.client, http.MethodPatch, jwtSigningKeyPath(data.ProjectRef.ValueString(), data.Id.ValueString()), body, &key)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to set JWT signing key status to %s, got error: %s", status, err)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		msg := fmt.Sprintf("Unable to set JWT signing key status to %s, got status %d: %s", status, httpResp.StatusCode, httpResp.Body)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("API Error", msg)}
+	}
+
+	updateDataFromJwtSigningKey(data, &key)
+	return nil
+}
+
+func jwtSigningKeysPath(projectRef string) string {
+	return fmt.Sprintf("/v1/projects/%s/config/auth/signing-keys", projectRef)
+}
+
+func jwtSigningKeyPath(projectRef string, id string) string {
+	return fmt.Sprintf("%s/%s", jwtSigningKeysPath(projectRef), id)
+}
+
+func updateDataFromJwtSigningKey(data *JwtSigningKeyResourceModel, key *jwtSigningKey) {
+	data.Id = types.StringValue(key.Id)
+	data.Algorithm = types.StringValue(key.Algorithm)
+	data.Status = types.StringValue(key.Status)
+	data.CreatedAt = types.StringValue(key.CreatedAt)
+	data.UpdatedAt = types.StringValue(key.UpdatedAt)
+
+	if len(key.PublicJwk) > 0 && string(key.PublicJwk) != "null" {
+		data.PublicJwk = types.StringValue(string(key.PublicJwk))
+	} else {
+		data.PublicJwk = types.StringNull()
+	}
+}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccJwtSigningKeyResource(t *testing.T) {
+	defer gock.OffAll()
+
+	standbyKey := map[string]interface{}{
+		"id":         "key-123",
+		"algorithm":  "ES256",
+		"status":     "standby",
+		"public_jwk": map[string]interface{}{"kty": "EC", "crv": "P-256", "kid": "key-123", "x": "x", "y": "y"},
+		"created_at": "2024-01-01T00:00:00Z",
+		"updated_at": "2024-01-01T00:00:00Z",
+	}
+
+	// Mock API responses for signing key CRUD operations
+	gock.New("https://api.supabase.com").
+		Post("/v1/projects/mayuaycdtijbctgqbycg/config/auth/signing-keys").
+		Reply(http.StatusCreated).
+		JSON(standbyKey)
+
+	gock.New("https://api.supabase.com").
+		Get("/v1/projects/mayuaycdtijbctgqbycg/config/auth/signing-keys/key-123").
+		Times(4).
+		Reply(http.StatusOK).
+		JSON(standbyKey)
+
+	gock.New("https://api.supabase.com").
+		Delete("/v1/projects/mayuaycdtijbctgqbycg/config/auth/signing-keys/key-123").
+		Reply(http.StatusOK).
+		JSON(standbyKey)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "supabase_jwt_signing_key" "test" {
+  project_ref = "mayuaycdtijbctgqbycg"
+  algorithm   = "ES256"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("supabase_jwt_signing_key.test", "id", "key-123"),
+					resource.TestCheckResourceAttr("supabase_jwt_signing_key.test", "status", "standby"),
+					resource.TestCheckResourceAttrSet("supabase_jwt_signing_key.test", "public_jwk"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "supabase_jwt_signing_key.test",
+				ImportState:       true,
+				ImportStateId:     "mayuaycdtijbctgqbycg/key-123",
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccJwtSigningKeysDataSource(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New("https://api.supabase.com").
+		Get("/v1/projects/mayuaycdtijbctgqbycg/config/auth/signing-keys").
+		Times(3).
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"keys": []map[string]interface{}{
+				{
+					"id":         "key-123",
+					"algorithm":  "ES256",
+					"status":     "in_use",
+					"public_jwk": map[string]interface{}{"kid": "key-123"},
+					"created_at": "2024-01-01T00:00:00Z",
+					"updated_at": "2024-01-01T00:00:00Z",
+				},
+				{
+					"id":         "key-456",
+					"algorithm":  "RS256",
+					"status":     "revoked",
+					"public_jwk": map[string]interface{}{"kid": "key-456"},
+					"created_at": "2023-01-01T00:00:00Z",
+					"updated_at": "2024-01-01T00:00:00Z",
+				},
+			},
+		})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "supabase_jwt_signing_keys" "all" {
+  project_ref = "mayuaycdtijbctgqbycg"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.supabase_jwt_signing_keys.all", "keys.#", "2"),
+					resource.TestCheckResourceAttr("data.supabase_jwt_signing_keys.all", "keys.0.status", "in_use"),
+					resource.TestCheckResourceAttr("data.supabase_jwt_signing_keys.all", "jwks", `{"keys":[{"kid":"key-123"}]}`),
+				),
+			},
+		},
+	})
+}
+
+// TestJwtSigningKeyAlgorithms checks that only asymmetric algorithms, whose
+// public keys can be published through JWKS, are accepted.
+func TestJwtSigningKeyAlgorithms(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	NewJwtSigningKeyResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	algorithm := schemaResp.Schema.Attributes["algorithm"].(schema.StringAttribute)
+
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{value: "RS256"},
+		{value: "ES256"},
+		{value: "HS256", wantErr: true},
+		{value: "EdDSA", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		resp := &validator.StringResponse{}
+		for _, v := range algorithm.Validators {
+			v.ValidateString(ctx, validator.StringRequest{
+				Path:        path.Root("algorithm"),
+				ConfigValue: types.StringValue(tt.value),
+			}, resp)
+		}
+		if resp.Diagnostics.HasError() != tt.wantErr {
+			t.Errorf("algorithm %s: expected error %t, got %v", tt.value, tt.wantErr, resp.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
+	"github.com/supabase/cli/pkg/api"
+)
+
+var (
+	_ datasource.DataSource              = &JwtSigningKeysDataSource{}
+	_ datasource.DataSourceWithConfigure = &JwtSigningKeysDataSource{}
+)
+
+func NewJwtSigningKeysDataSource() datasource.DataSource {
+	return &JwtSigningKeysDataSource{}
+}
+
+type JwtSigningKeysDataSource struct {
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
+}
+
+type JwtSigningKeysDataSourceModel struct {
+	ProjectRef types.String                 `tfsdk:"project_ref"`
+	Keys       []JwtSigningKeyDataSourceKey `tfsdk:"keys"`
+	Jwks       types.String                 `tfsdk:"jwks"`
+	Id         types.String                 `tfsdk:"id"`
+}
+
+type JwtSigningKeyDataSourceKey struct {
+	Id        types.String `tfsdk:"id"`
+	Algorithm types.String `tfsdk:"algorithm"`
+	Status    types.String `tfsdk:"status"`
+	PublicJwk types.String `tfsdk:"public_jwk"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+}
+
+func (d *JwtSigningKeysDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwt_signing_keys"
+}
+
+func (d *JwtSigningKeysDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+
+Retrieves the JWT signing keys of a Supabase project and the public JWKS used to verify tokens.
+
+## Example Usage
+
+~~~hcl
+data "supabase_jwt_signing_keys" "all" {
+  project_ref = "abcdefghijklmnopqrst"
+}
+~~~
+`,
+		Attributes: map[string]schema.Attribute{
+			"project_ref": schema.StringAttribute{
+				MarkdownDescription: "Project reference ID. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Same as project_ref",
+				Computed:            true,
+			},
+			"jwks": schema.StringAttribute{
+				MarkdownDescription: "JSON encoded JWKS with the public keys of all keys that are not revoked",
+				Computed:            true,
+			},
+			"keys": schema.ListNestedAttribute{
+				MarkdownDescription: "List of JWT signing keys",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Signing key ID",
+							Computed:            true,
+						},
+						"algorithm": schema.StringAttribute{
+							MarkdownDescription: "Signing algorithm",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "Key status",
+							Computed:            true,
+						},
+						"public_jwk": schema.StringAttribute{
+							MarkdownDescription: "Public key as a JSON encoded JWK",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "When the signing key was created",
+							Computed:            true,
+						},
+						"updated_at": schema.StringAttribute{
+							MarkdownDescription: "When the signing key was last updated",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *JwtSigningKeysDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*settings.SupabaseProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *settings.SupabaseProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(providerData.RequireManagementAPI("supabase_jwt_signing_keys")...)
+	d.client = providerData.ManagementClient
+	d.providerData = providerData
+}
+
+func (d *JwtSigningKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Skipped data sources return their configuration
+	if d.providerData.SkipsManagementAPI() {
+		return
+	}
+
+	var data JwtSigningKeysDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(d.providerData.ResolveProjectRef(&data.ProjectRef)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var list jwtSigningKeyList
+	httpResp, err := managementRequest(ctx, d.client, http.MethodGet, jwtSigningKeysPath(data.ProjectRef.ValueString()), nil, &list)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read JWT signing keys: %s", err))
+		return
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to read JWT signing keys, got status %d: %s", httpResp.StatusCode, httpResp.Body))
+		return
+	}
+
+	jwks := struct {
+		Keys []json.RawMessage `json:"keys"`
+	}{Keys: []json.RawMessage{}}
+
+	data.Keys = make([]JwtSigningKeyDataSourceKey, len(list.Keys))
+	for i, key := range list.Keys {
+		data.Keys[i] = JwtSigningKeyDataSourceKey{
+			Id:        types.StringValue(key.Id),
+			Algorithm: types.StringValue(key.Algorithm),
+			Status:    types.StringValue(key.Status),
+			PublicJwk: types.StringNull(),
+			CreatedAt: types.StringValue(key.CreatedAt),
+			UpdatedAt: types.StringValue(key.UpdatedAt),
+		}
+
+		if len(key.PublicJwk) == 0 || string(key.PublicJwk) == "null" {
+			continue
+		}
+		data.Keys[i].PublicJwk = types.StringValue(string(key.PublicJwk))
+
+		if key.Status != jwtSigningKeyStatusRevoked {
+			jwks.Keys = append(jwks.Keys, key.PublicJwk)
+		}
+	}
+
+	encoded, err := json.Marshal(jwks)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to encode JWKS: %s", err))
+		return
+	}
+
+	data.Jwks = types.StringValue(string(encoded))
+	data.Id = data.ProjectRef
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
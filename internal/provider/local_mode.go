@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"os"
+
+	"github.com/supabase/cli/pkg/config"
+)
+
+// localProject holds the connection details of a local stack started with
+// `supabase start`.
+type localProject struct {
+	ProjectId      string
+	APIURL         string
+	AnonKey        string
+	ServiceRoleKey string
+}
+
+// loadLocalProject reads supabase/config.toml from workdir the same way the
+// Supabase CLI does, including the API keys it derives from the JWT secret.
+func loadLocalProject(workdir string) (*localProject, error) {
+	cfg := config.NewConfig()
+	if err := cfg.Load("", os.DirFS(workdir)); err != nil {
+		return nil, err
+	}
+
+	return &localProject{
+		ProjectId:      cfg.ProjectId,
+		APIURL:         cfg.Api.ExternalUrl,
+		AnonKey:        cfg.Auth.AnonKey,
+		ServiceRoleKey: cfg.Auth.ServiceRoleKey,
+	}, nil
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/supabase/cli/pkg/api"
+)
+
+// managementResponse holds the raw result of a Management API call made
+// outside of the generated client.
+type managementResponse struct {
+	StatusCode int
+	Body       []byte
+}
+
+// managementRequest calls a Management API endpoint that the generated client
+// does not cover yet. The request goes through the same server, transport and
+// request editors (auth and user agent headers) as the generated client. When
+// out is non-nil and the call succeeds, the response body is decoded into it.
+func managementRequest(ctx context.Context, client *api.ClientWithResponses, method string, path string, body any, out any) (*managementResponse, error) {
+	raw, ok := client.ClientInterface.(*api.Client)
+	if !ok {
+		return nil, fmt.Errorf("unexpected management client type: %T", client.ClientInterface)
+	}
+
+	serverURL, err := url.Parse(raw.Server)
+	if err != nil {
+		return nil, err
+	}
+	queryURL, err := serverURL.Parse("." + path)
+	if err != nil {
+		return nil, err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, queryURL.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	for _, editor := range raw.RequestEditors {
+		if err := editor(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	httpResp, err := raw.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &managementResponse{
+		StatusCode: httpResp.StatusCode,
+		Body:       respBody,
+	}
+
+	if out != nil && httpResp.StatusCode >= 200 && httpResp.StatusCode < 300 && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return resp, fmt.Errorf("failed to decode response body: %w", err)
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestManagementRequest(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New("https://api.supabase.com").
+		Patch("/v1/projects/mayuaycdtijbctgqbycg/config/auth/signing-keys/key-123").
+		MatchHeader("Authorization", "Bearer test-token").
+		MatchType("json").
+		JSON(map[string]string{"status": "in_use"}).
+		Reply(http.StatusOK).
+		JSON(map[string]string{"id": "key-123", "status": "in_use"})
+
+	client, err := api.NewClientWithResponses(
+		"https://api.supabase.com",
+		api.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer test-token")
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error creating client: %s", err)
+	}
+
+	var key jwtSigningKey
+	resp, err := managementRequest(
+		context.Background(),
+		client,
+		http.MethodPatch,
+		jwtSigningKeyPath("mayuaycdtijbctgqbycg", "key-123"),
+		jwtSigningKeyUpdateBody{Status: "in_use"},
+		&key,
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	if key.Id != "key-123" || key.Status != "in_use" {
+		t.Errorf("Expected decoded key-123 in_use, got %s %s", key.Id, key.Status)
+	}
+
+	if !gock.IsDone() {
+		t.Errorf("Expected all mocked requests to be called")
+	}
+}
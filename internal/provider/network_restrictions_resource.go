@@ -0,0 +1,414 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
+	"github.com/supabase/cli/pkg/api"
+)
+
+var (
+	_ resource.Resource                = &NetworkRestrictionsResource{}
+	_ resource.ResourceWithConfigure   = &NetworkRestrictionsResource{}
+	_ resource.ResourceWithImportState = &NetworkRestrictionsResource{}
+	_ resource.ResourceWithModifyPlan  = &NetworkRestrictionsResource{}
+	_ resource.ResourceWithMoveState   = &NetworkRestrictionsResource{}
+)
+
+// networkAllowAll are the CIDRs that lift every network restriction, which
+// is the state of a new project.
+var networkAllowAll = []string{"0.0.0.0/0", "::/0"}
+
+// privateNetworks are the ranges the network restrictions API refuses, as in
+// net.IP.IsPrivate.
+var privateNetworks = []netip.Prefix{
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+	netip.MustParsePrefix("fc00::/7"),
+}
+
+func NewNetworkRestrictionsResource() resource.Resource {
+	return &NetworkRestrictionsResource{}
+}
+
+// NetworkRestrictionsResource manages the CIDRs allowed to connect to the
+// database of a project.
+type NetworkRestrictionsResource struct {
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
+}
+
+type NetworkRestrictionsResourceModel struct {
+	ProjectRef   types.String `tfsdk:"project_ref"`
+	Id           types.String `tfsdk:"id"`
+	AllowedCidrs types.Set    `tfsdk:"allowed_cidrs"`
+	Status       types.String `tfsdk:"status"`
+	Entitlement  types.String `tfsdk:"entitlement"`
+}
+
+func (r *NetworkRestrictionsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network_restrictions"
+}
+
+func (r *NetworkRestrictionsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Manages the IPv4 and IPv6 CIDRs allowed to connect to the database of a Supabase project. Destroying the resource allows connections from everywhere again.
+
+Do not combine it with the ` + "`network`" + ` block of ` + "`supabase_settings`" + ` for the same project. The state of that block can be moved to this resource with a ` + "`moved`" + ` block.
+
+Refer to the [Supabase network restrictions documentation](https://supabase.com/docs/guides/platform/network-restrictions) for more information.
+
+## Example Usage
+
+~~~hcl
+resource "supabase_network_restrictions" "production" {
+  project_ref = "mayuaycdtijbctgqbycg"
+
+  allowed_cidrs = [
+    "203.0.113.0/24",
+    "2001:db8::/32",
+  ]
+}
+~~~
+`,
+		Attributes: map[string]schema.Attribute{
+			"project_ref": schema.StringAttribute{
+				MarkdownDescription: "Project reference ID. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Project reference ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"allowed_cidrs": schema.SetAttribute{
+				MarkdownDescription: "IPv4 and IPv6 CIDRs allowed to connect to the database, such as `203.0.113.0/24` or `2001:db8::/32`. Use `0.0.0.0/0` and `::/0` to allow every address. Private ranges are not allowed.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(networkCidrValidator{}),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Whether the restrictions are `applied` to the database or only `stored`",
+				Computed:            true,
+			},
+			"entitlement": schema.StringAttribute{
+				MarkdownDescription: "Whether the plan of the organization `allowed` or `disallowed` network restrictions",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *NetworkRestrictionsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*settings.SupabaseProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *settings.SupabaseProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(providerData.RequireManagementAPI("supabase_network_restrictions")...)
+	r.client = providerData.ManagementClient
+	r.providerData = providerData
+}
+
+// ModifyPlan falls back to the provider project_ref when the resource omits it.
+func (r *NetworkRestrictionsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.PlanProjectRef(ctx, req, resp)
+}
+
+func (r *NetworkRestrictionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(settings.SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	var data NetworkRestrictionsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "applied network restrictions")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NetworkRestrictionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Skipped resources keep their prior state
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
+	var data NetworkRestrictionsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.V1GetNetworkRestrictionsWithResponse(ctx, data.ProjectRef.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read network restrictions: %s", err))
+		return
+	}
+
+	if httpResp.StatusCode() == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if httpResp.JSON200 == nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to read network restrictions, got status %d: %s", httpResp.StatusCode(), httpResp.Body))
+		return
+	}
+
+	resp.Diagnostics.Append(updateDataFromNetworkRestrictions(ctx, &data, httpResp.JSON200)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NetworkRestrictionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(settings.SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	var data NetworkRestrictionsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NetworkRestrictionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
+	var data NetworkRestrictionsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.V1UpdateNetworkRestrictionsWithResponse(ctx, data.ProjectRef.ValueString(), networkRestrictionsBody(networkAllowAll))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset network restrictions: %s", err))
+		return
+	}
+
+	if httpResp.JSON201 == nil && httpResp.StatusCode() != http.StatusNotFound {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to reset network restrictions, got status %d: %s", httpResp.StatusCode(), httpResp.Body))
+		return
+	}
+}
+
+func (r *NetworkRestrictionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !projectRefPattern.MatchString(req.ID) {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected a project reference as import ID, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_ref"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// MoveState moves the network block of a supabase_settings resource, whose
+// IPv4 and IPv6 lists become a single set.
+func (r *NetworkRestrictionsResource) MoveState(ctx context.Context) []resource.StateMover {
+	sourceSchema := settings.SettingsSchema()
+
+	return []resource.StateMover{
+		{
+			SourceSchema: &sourceSchema,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				var source settings.SettingsResourceModel
+				if !settings.IsSettingsMoveSource(ctx, req, &source, &resp.Diagnostics) {
+					return
+				}
+
+				cidrs := []string{}
+				if source.Network != nil {
+					for _, cidr := range append(source.Network.DbAllowedCidrs, source.Network.DbAllowedCidrsV6...) {
+						cidrs = append(cidrs, cidr.ValueString())
+					}
+				}
+				allowedCidrs, diags := types.SetValueFrom(ctx, types.StringType, cidrs)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data := NetworkRestrictionsResourceModel{
+					ProjectRef:   source.ProjectRef,
+					Id:           source.Id,
+					AllowedCidrs: allowedCidrs,
+					Status:       types.StringNull(),
+					Entitlement:  types.StringNull(),
+				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
+// apply replaces the network restrictions of the project with the planned
+// CIDRs.
+func (r *NetworkRestrictionsResource) apply(ctx context.Context, data *NetworkRestrictionsResourceModel) diag.Diagnostics {
+	var cidrs []string
+	diags := data.AllowedCidrs.ElementsAs(ctx, &cidrs, false)
+	if diags.HasError() {
+		return diags
+	}
+
+	httpResp, err := r.client.V1UpdateNetworkRestrictionsWithResponse(ctx, data.ProjectRef.ValueString(), networkRestrictionsBody(cidrs))
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to apply network restrictions: %s", err))}
+	}
+
+	if httpResp.JSON201 == nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("API Error", fmt.Sprintf("Unable to apply network restrictions, got status %d: %s", httpResp.StatusCode(), httpResp.Body))}
+	}
+
+	return updateDataFromNetworkRestrictions(ctx, data, httpResp.JSON201)
+}
+
+// networkRestrictionsBody sorts CIDRs into the IPv4 and IPv6 lists of the API.
+func networkRestrictionsBody(cidrs []string) api.NetworkRestrictionsRequest {
+	v4 := []string{}
+	v6 := []string{}
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err == nil && prefix.Addr().Is6() {
+			v6 = append(v6, cidr)
+		} else {
+			v4 = append(v4, cidr)
+		}
+	}
+	sort.Strings(v4)
+	sort.Strings(v6)
+
+	return api.NetworkRestrictionsRequest{
+		DbAllowedCidrs:   &v4,
+		DbAllowedCidrsV6: &v6,
+	}
+}
+
+func updateDataFromNetworkRestrictions(ctx context.Context, data *NetworkRestrictionsResourceModel, restrictions *api.NetworkRestrictionsResponse) diag.Diagnostics {
+	var cidrs []string
+	if v4 := restrictions.Config.DbAllowedCidrs; v4 != nil {
+		cidrs = append(cidrs, *v4...)
+	}
+	if v6 := restrictions.Config.DbAllowedCidrsV6; v6 != nil {
+		cidrs = append(cidrs, *v6...)
+	}
+	// A project that was never restricted has no configuration
+	if restrictions.Config.DbAllowedCidrs == nil && restrictions.Config.DbAllowedCidrsV6 == nil {
+		cidrs = networkAllowAll
+	}
+
+	allowedCidrs, diags := types.SetValueFrom(ctx, types.StringType, cidrs)
+	if diags.HasError() {
+		return diags
+	}
+
+	data.Id = data.ProjectRef
+	data.AllowedCidrs = allowedCidrs
+	data.Status = types.StringValue(string(restrictions.Status))
+	data.Entitlement = types.StringValue(string(restrictions.Entitlement))
+	return nil
+}
+
+// validateNetworkCidr checks that a CIDR is in the canonical form the API
+// returns, so that it does not show a diff after apply, and that it is not
+// entirely within a private range.
+func validateNetworkCidr(cidr string) error {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return fmt.Errorf("%q is not a CIDR, such as 203.0.113.0/24 or 2001:db8::/32", cidr)
+	}
+
+	if canonical := prefix.Masked().String(); canonical != cidr {
+		return fmt.Errorf("%q is not in canonical form, use %q", cidr, canonical)
+	}
+
+	for _, private := range privateNetworks {
+		if private.Bits() <= prefix.Bits() && private.Contains(prefix.Addr()) {
+			return fmt.Errorf("%q is within the private range %s", cidr, private)
+		}
+	}
+
+	return nil
+}
+
+// networkCidrValidator validates the entries of allowed_cidrs.
+type networkCidrValidator struct{}
+
+func (v networkCidrValidator) Description(ctx context.Context) string {
+	return "value must be a public IPv4 or IPv6 CIDR in canonical form"
+}
+
+func (v networkCidrValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v networkCidrValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateNetworkCidr(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid CIDR", err.Error())
+	}
+}
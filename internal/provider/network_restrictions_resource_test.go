@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccNetworkRestrictionsResource(t *testing.T) {
+	defer gock.OffAll()
+
+	// Create, with the CIDRs sorted into their address families
+	gock.New("https://api.supabase.com").
+		Post("/v1/projects/mayuaycdtijbctgqbycg/network-restrictions/apply").
+		MatchType("json").
+		JSON(api.NetworkRestrictionsRequest{
+			DbAllowedCidrs:   &[]string{"203.0.113.0/24"},
+			DbAllowedCidrsV6: &[]string{"2001:db8::/32"},
+		}).
+		Reply(http.StatusCreated).
+		JSON(api.NetworkRestrictionsResponse{
+			Config: api.NetworkRestrictionsRequest{
+				DbAllowedCidrs:   &[]string{"203.0.113.0/24"},
+				DbAllowedCidrsV6: &[]string{"2001:db8::/32"},
+			},
+			Entitlement: api.Allowed,
+			Status:      api.Applied,
+		})
+	gock.New("https://api.supabase.com").
+		Get("/v1/projects/mayuaycdtijbctgqbycg/network-restrictions").
+		Times(3).
+		Reply(http.StatusOK).
+		JSON(api.NetworkRestrictionsResponse{
+			Config: api.NetworkRestrictionsRequest{
+				DbAllowedCidrs:   &[]string{"203.0.113.0/24"},
+				DbAllowedCidrsV6: &[]string{"2001:db8::/32"},
+			},
+			Entitlement: api.Allowed,
+			Status:      api.Applied,
+		})
+
+	// Update
+	gock.New("https://api.supabase.com").
+		Post("/v1/projects/mayuaycdtijbctgqbycg/network-restrictions/apply").
+		MatchType("json").
+		JSON(api.NetworkRestrictionsRequest{
+			DbAllowedCidrs:   &[]string{"198.51.100.0/24", "203.0.113.0/24"},
+			DbAllowedCidrsV6: &[]string{},
+		}).
+		Reply(http.StatusCreated).
+		JSON(api.NetworkRestrictionsResponse{
+			Config: api.NetworkRestrictionsRequest{
+				DbAllowedCidrs:   &[]string{"198.51.100.0/24", "203.0.113.0/24"},
+				DbAllowedCidrsV6: &[]string{},
+			},
+			Entitlement: api.Allowed,
+			Status:      api.Applied,
+		})
+	gock.New("https://api.supabase.com").
+		Get("/v1/projects/mayuaycdtijbctgqbycg/network-restrictions").
+		Times(3).
+		Reply(http.StatusOK).
+		JSON(api.NetworkRestrictionsResponse{
+			Config: api.NetworkRestrictionsRequest{
+				DbAllowedCidrs:   &[]string{"203.0.113.0/24", "198.51.100.0/24"},
+				DbAllowedCidrsV6: &[]string{},
+			},
+			Entitlement: api.Allowed,
+			Status:      api.Applied,
+		})
+
+	// Delete resets to allow all
+	gock.New("https://api.supabase.com").
+		Post("/v1/projects/mayuaycdtijbctgqbycg/network-restrictions/apply").
+		MatchType("json").
+		JSON(api.NetworkRestrictionsRequest{
+			DbAllowedCidrs:   &[]string{"0.0.0.0/0"},
+			DbAllowedCidrsV6: &[]string{"::/0"},
+		}).
+		Reply(http.StatusCreated).
+		JSON(api.NetworkRestrictionsResponse{
+			Config: api.NetworkRestrictionsRequest{
+				DbAllowedCidrs:   &[]string{"0.0.0.0/0"},
+				DbAllowedCidrsV6: &[]string{"::/0"},
+			},
+			Entitlement: api.Allowed,
+			Status:      api.Applied,
+		})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccNetworkRestrictionsResourceConfig(`"2001:db8::/32", "203.0.113.0/24"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("supabase_network_restrictions.test", "id", "mayuaycdtijbctgqbycg"),
+					resource.TestCheckResourceAttr("supabase_network_restrictions.test", "allowed_cidrs.#", "2"),
+					resource.TestCheckTypeSetElemAttr("supabase_network_restrictions.test", "allowed_cidrs.*", "2001:db8::/32"),
+					resource.TestCheckResourceAttr("supabase_network_restrictions.test", "status", "applied"),
+					resource.TestCheckResourceAttr("supabase_network_restrictions.test", "entitlement", "allowed"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "supabase_network_restrictions.test",
+				ImportState:       true,
+				ImportStateId:     "mayuaycdtijbctgqbycg",
+				ImportStateVerify: true,
+			},
+			// Update and Read testing, where the API reorders the CIDRs
+			{
+				Config: testAccNetworkRestrictionsResourceConfig(`"198.51.100.0/24", "203.0.113.0/24"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("supabase_network_restrictions.test", "allowed_cidrs.#", "2"),
+					resource.TestCheckTypeSetElemAttr("supabase_network_restrictions.test", "allowed_cidrs.*", "198.51.100.0/24"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccNetworkRestrictionsResourceInvalidCidr(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNetworkRestrictionsResourceConfig(`"10.1.0.0/16"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`within the private range`),
+			},
+		},
+	})
+}
+
+func testAccNetworkRestrictionsResourceConfig(cidrs string) string {
+	return `
+resource "supabase_network_restrictions" "test" {
+  project_ref   = "mayuaycdtijbctgqbycg"
+  allowed_cidrs = [` + cidrs + `]
+}
+`
+}
+
+func TestValidateNetworkCidr(t *testing.T) {
+	tests := []struct {
+		cidr    string
+		wantErr bool
+	}{
+		{cidr: "0.0.0.0/0"},
+		{cidr: "::/0"},
+		{cidr: "203.0.113.0/24"},
+		{cidr: "2001:db8::/32"},
+		{cidr: "8.0.0.0/7"},
+		// Spans private ranges without being within one
+		{cidr: "0.0.0.0/1"},
+		{cidr: "10.0.0.0/8", wantErr: true},
+		{cidr: "192.168.1.0/24", wantErr: true},
+		{cidr: "fd00::/8", wantErr: true},
+		{cidr: "203.0.113.7/24", wantErr: true},
+		{cidr: "2001:DB8::/32", wantErr: true},
+		{cidr: "203.0.113.7", wantErr: true},
+		{cidr: "localhost", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := validateNetworkCidr(tt.cidr)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateNetworkCidr(%q) error = %v, wantErr %t", tt.cidr, err, tt.wantErr)
+		}
+	}
+}
+
+func TestNetworkRestrictionsBody(t *testing.T) {
+	body := networkRestrictionsBody([]string{"2001:db8::/32", "203.0.113.0/24", "::/0", "0.0.0.0/0"})
+
+	if got := *body.DbAllowedCidrs; len(got) != 2 || got[0] != "0.0.0.0/0" || got[1] != "203.0.113.0/24" {
+		t.Errorf("unexpected IPv4 CIDRs: %v", got)
+	}
+	if got := *body.DbAllowedCidrsV6; len(got) != 2 || got[0] != "2001:db8::/32" || got[1] != "::/0" {
+		t.Errorf("unexpected IPv6 CIDRs: %v", got)
+	}
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ParseRefFunction{}
+
+func NewParseRefFunction() function.Function {
+	return &ParseRefFunction{}
+}
+
+// ParseRefFunction extracts the project reference from a Supabase URL.
+type ParseRefFunction struct{}
+
+func (f *ParseRefFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_ref"
+}
+
+func (f *ParseRefFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Extract the project reference from a Supabase URL",
+		MarkdownDescription: "Extracts the project reference from a project API URL such as `https://<ref>.supabase.co`, a database host such as `db.<ref>.supabase.co`, " +
+			"a direct or pooler connection URI, or a dashboard URL such as `https://supabase.com/dashboard/project/<ref>`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "url",
+				MarkdownDescription: "URL, hostname, or connection URI of a project",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ParseRefFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	ref, err := parseRef(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, ref))
+}
+
+// parseRef returns the project reference found in a URL, hostname, or
+// connection URI.
+func parseRef(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if projectRefPattern.MatchString(value) {
+		return value, nil
+	}
+
+	// Hostnames without a scheme parse as a path otherwise
+	raw := value
+	if !strings.Contains(raw, "://") {
+		raw = "//" + raw
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse %q: %w", value, err)
+	}
+
+	// Pooler connections log in as <user>.<ref>
+	if parsed.User != nil {
+		if _, ref, ok := strings.Cut(parsed.User.Username(), "."); ok && projectRefPattern.MatchString(ref) {
+			return ref, nil
+		}
+	}
+
+	// <ref>.supabase.co and db.<ref>.supabase.co
+	labels := strings.Split(parsed.Hostname(), ".")
+	if len(labels) > 1 && labels[0] == "db" {
+		labels = labels[1:]
+	}
+	if len(labels) > 2 && projectRefPattern.MatchString(labels[0]) {
+		return labels[0], nil
+	}
+
+	// Dashboard URLs, https://supabase.com/dashboard/project/<ref>/...
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] == "project" && projectRefPattern.MatchString(segments[i+1]) {
+			return segments[i+1], nil
+		}
+	}
+
+	return "", fmt.Errorf("no project reference found in %q", value)
+}
@@ -22,7 +22,8 @@ func NewPoolerDataSource() datasource.DataSource {
 
 // PoolerDataSource defines the data source implementation.
 type PoolerDataSource struct {
-	client *api.ClientWithResponses
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
 }
 
 // PoolerDataSourceModel describes the data source data model.
@@ -42,8 +43,9 @@ func (d *PoolerDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 
 		Attributes: map[string]schema.Attribute{
 			"project_ref": schema.StringAttribute{
-				MarkdownDescription: "Project ref",
-				Required:            true,
+				MarkdownDescription: "Project ref. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"url": schema.MapAttribute{
 				MarkdownDescription: "Map of pooler mode to connection string",
@@ -71,10 +73,17 @@ func (d *PoolerDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
+	resp.Diagnostics.Append(providerData.RequireManagementAPI("supabase_pooler")...)
 	d.client = providerData.ManagementClient
+	d.providerData = providerData
 }
 
 func (d *PoolerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Skipped data sources return their configuration
+	if d.providerData.SkipsManagementAPI() {
+		return
+	}
+
 	var projectRef types.String
 
 	// Read Terraform configuration data into the model
@@ -82,6 +91,10 @@ func (d *PoolerDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(d.providerData.ResolveProjectRef(&projectRef)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
@@ -109,5 +122,6 @@ func (d *PoolerDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	tflog.Trace(ctx, "read a data source")
 
 	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_ref"), projectRef)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("url"), url)...)
 }
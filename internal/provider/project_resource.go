@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
@@ -20,6 +22,43 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ProjectResource{}
 var _ resource.ResourceWithImportState = &ProjectResource{}
+var _ resource.ResourceWithModifyPlan = &ProjectResource{}
+
+// projectRegions are the regions a project can be created in.
+var projectRegions = []string{
+	string(api.V1CreateProjectBodyDtoRegionApEast1),
+	string(api.V1CreateProjectBodyDtoRegionApNortheast1),
+	string(api.V1CreateProjectBodyDtoRegionApNortheast2),
+	string(api.V1CreateProjectBodyDtoRegionApSouth1),
+	string(api.V1CreateProjectBodyDtoRegionApSoutheast1),
+	string(api.V1CreateProjectBodyDtoRegionApSoutheast2),
+	string(api.V1CreateProjectBodyDtoRegionCaCentral1),
+	string(api.V1CreateProjectBodyDtoRegionEuCentral1),
+	string(api.V1CreateProjectBodyDtoRegionEuCentral2),
+	string(api.V1CreateProjectBodyDtoRegionEuNorth1),
+	string(api.V1CreateProjectBodyDtoRegionEuWest1),
+	string(api.V1CreateProjectBodyDtoRegionEuWest2),
+	string(api.V1CreateProjectBodyDtoRegionEuWest3),
+	string(api.V1CreateProjectBodyDtoRegionSaEast1),
+	string(api.V1CreateProjectBodyDtoRegionUsEast1),
+	string(api.V1CreateProjectBodyDtoRegionUsEast2),
+	string(api.V1CreateProjectBodyDtoRegionUsWest1),
+	string(api.V1CreateProjectBodyDtoRegionUsWest2),
+}
+
+// projectInstanceSizes are the instance sizes a project can be created with.
+var projectInstanceSizes = []string{
+	string(api.V1CreateProjectBodyDtoDesiredInstanceSizeMicro),
+	string(api.V1CreateProjectBodyDtoDesiredInstanceSizeSmall),
+	string(api.V1CreateProjectBodyDtoDesiredInstanceSizeMedium),
+	string(api.V1CreateProjectBodyDtoDesiredInstanceSizeLarge),
+	string(api.V1CreateProjectBodyDtoDesiredInstanceSizeXlarge),
+	string(api.V1CreateProjectBodyDtoDesiredInstanceSizeN2xlarge),
+	string(api.V1CreateProjectBodyDtoDesiredInstanceSizeN4xlarge),
+	string(api.V1CreateProjectBodyDtoDesiredInstanceSizeN8xlarge),
+	string(api.V1CreateProjectBodyDtoDesiredInstanceSizeN12xlarge),
+	string(api.V1CreateProjectBodyDtoDesiredInstanceSizeN16xlarge),
+}
 
 func NewProjectResource() resource.Resource {
 	return &ProjectResource{}
@@ -27,7 +66,8 @@ func NewProjectResource() resource.Resource {
 
 // ProjectResource defines the resource implementation.
 type ProjectResource struct {
-	client *api.ClientWithResponses
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
 }
 
 // ProjectResourceModel describes the resource data model.
@@ -50,8 +90,12 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 
 		Attributes: map[string]schema.Attribute{
 			"organization_id": schema.StringAttribute{
-				MarkdownDescription: "Reference to the organization",
-				Required:            true,
+				MarkdownDescription: "Reference to the organization. Defaults to the provider `organization_id`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Name of the project",
@@ -65,10 +109,16 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 			"region": schema.StringAttribute{
 				MarkdownDescription: "Region where the project is located",
 				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(projectRegions...),
+				},
 			},
 			"instance_size": schema.StringAttribute{
 				MarkdownDescription: "Desired instance size of the project",
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(projectInstanceSizes...),
+				},
 			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Project identifier",
@@ -96,10 +146,23 @@ func (r *ProjectResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
+	resp.Diagnostics.Append(providerData.RequireManagementAPI("supabase_project")...)
 	r.client = providerData.ManagementClient
+	r.providerData = providerData
+}
+
+// ModifyPlan falls back to the provider organization_id when the resource
+// omits it.
+func (r *ProjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.PlanOrganizationId(ctx, req, resp)
 }
 
 func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(settings.SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
 	var data ProjectResourceModel
 
 	// Read Terraform plan data into the model
@@ -120,6 +183,11 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 }
 
 func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Skipped resources keep their prior state
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
 	var data ProjectResourceModel
 
 	// Read Terraform prior state data into the model
@@ -140,6 +208,11 @@ func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(settings.SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
 	var data ProjectResourceModel
 
 	// Read Terraform plan data into the model
@@ -156,6 +229,10 @@ func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
 	var data ProjectResourceModel
 
 	// Read Terraform prior state data into the model
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ProjectURLFunction{}
+
+func NewProjectURLFunction() function.Function {
+	return &ProjectURLFunction{}
+}
+
+// ProjectURLFunction returns the API URL of a project.
+type ProjectURLFunction struct{}
+
+func (f *ProjectURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "project_url"
+}
+
+func (f *ProjectURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Return the API URL of a project",
+		MarkdownDescription: "Returns the API URL of a project, `https://<ref>.supabase.co`, which the Supabase client libraries connect to.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "ref",
+				MarkdownDescription: "Project reference",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ProjectURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ref string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &ref))
+	if resp.Error != nil {
+		return
+	}
+
+	if !projectRefPattern.MatchString(ref) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("%q is not a project reference", ref))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, projectURL(ref)))
+}
+
+// projectURL returns the API URL of a project.
+func projectURL(ref string) string {
+	return fmt.Sprintf("https://%s.supabase.co", ref)
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProjectURLFunctionRun(t *testing.T) {
+	cases := []struct {
+		ref     string
+		want    string
+		wantErr bool
+	}{
+		{"mayuaycdtijbctgqbycg", "https://mayuaycdtijbctgqbycg.supabase.co", false},
+		{"https://mayuaycdtijbctgqbycg.supabase.co", "", true},
+		{"not-a-ref", "", true},
+		{"", "", true},
+	}
+
+	for _, c := range cases {
+		req := function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(c.ref)}),
+		}
+		resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+
+		NewProjectURLFunction().Run(context.Background(), req, resp)
+
+		if c.wantErr {
+			if resp.Error == nil {
+				t.Errorf("Expected error for %q", c.ref)
+			} else if resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != 0 {
+				t.Errorf("Expected an error on the ref argument for %q, got %s", c.ref, resp.Error)
+			}
+			continue
+		}
+
+		if resp.Error != nil {
+			t.Errorf("Unexpected error for %q: %s", c.ref, resp.Error)
+			continue
+		}
+		if want := types.StringValue(c.want); !resp.Result.Value().Equal(want) {
+			t.Errorf("Expected %s, got %s", want, resp.Result.Value())
+		}
+	}
+}
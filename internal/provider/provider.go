@@ -2,14 +2,25 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/supabase/cli/pkg/api"
 
 	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
@@ -17,6 +28,11 @@ import (
 
 // Ensure SupabaseProvider satisfies various provider interfaces.
 var _ provider.Provider = &SupabaseProvider{}
+var _ provider.ProviderWithEphemeralResources = &SupabaseProvider{}
+var _ provider.ProviderWithFunctions = &SupabaseProvider{}
+
+// projectRefPattern matches the reference of a project on the Supabase platform
+var projectRefPattern = settings.ProjectRefPattern
 
 // SupabaseProvider defines the provider implementation.
 type SupabaseProvider struct {
@@ -28,8 +44,23 @@ type SupabaseProvider struct {
 
 // SupabaseProviderModel describes the provider data model.
 type SupabaseProviderModel struct {
-	Endpoint    types.String `tfsdk:"endpoint"`
-	AccessToken types.String `tfsdk:"access_token"`
+	Endpoint              types.String `tfsdk:"endpoint"`
+	AccessToken           types.String `tfsdk:"access_token"`
+	StorageUrl            types.String `tfsdk:"storage_url"`
+	ServiceRoleKey        types.String `tfsdk:"service_role_key"`
+	Mode                  types.String `tfsdk:"mode"`
+	Workdir               types.String `tfsdk:"workdir"`
+	MaxRetries            types.Int64  `tfsdk:"max_retries"`
+	RequestTimeout        types.Int64  `tfsdk:"request_timeout"`
+	MaxRequestsPerMinute  types.Int64  `tfsdk:"max_requests_per_minute"`
+	Profile               types.String `tfsdk:"profile"`
+	ProfilesFile          types.String `tfsdk:"profiles_file"`
+	OAuthClientId         types.String `tfsdk:"oauth_client_id"`
+	OAuthClientSecret     types.String `tfsdk:"oauth_client_secret"`
+	OAuthRefreshToken     types.String `tfsdk:"oauth_refresh_token"`
+	OAuthRefreshTokenFile types.String `tfsdk:"oauth_refresh_token_file"`
+	ProjectRef            types.String `tfsdk:"project_ref"`
+	OrganizationId        types.String `tfsdk:"organization_id"`
 }
 
 
@@ -46,10 +77,99 @@ func (p *SupabaseProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Optional:            true,
 			},
 			"access_token": schema.StringAttribute{
-				MarkdownDescription: "Supabase access token",
+				MarkdownDescription: "Supabase access token. Can also be set with the `SUPABASE_ACCESS_TOKEN` environment variable. When neither is set, the token is read from the selected profile, then from the login of the Supabase CLI.",
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"storage_url": schema.StringAttribute{
+				MarkdownDescription: "Storage API URL template used by storage resources. `{ref}` is replaced with the project reference, for example `https://{ref}.example.com`. Use `http://localhost:54321` for a local stack started with `supabase start`. Defaults to the custom hostname of the project when one is active, and `https://{ref}.supabase.co` otherwise. Can also be set with the `SUPABASE_STORAGE_URL` environment variable.",
+				Optional:            true,
+			},
+			"service_role_key": schema.StringAttribute{
+				MarkdownDescription: "Service role key used by storage resources instead of fetching the key of each project through the Management API. Can also be set with the `SUPABASE_SERVICE_ROLE_KEY` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"mode": schema.StringAttribute{
+				MarkdownDescription: "Either `remote` to manage projects on the Supabase platform, or `local` to target the local stack started with `supabase start`. In local mode, storage resources use the API URL and service role key of the local project, and resources that require the Management API are skipped with a warning. Defaults to `remote`. Can also be set with the `SUPABASE_MODE` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(settings.ModeRemote, settings.ModeLocal),
+				},
+			},
+			"workdir": schema.StringAttribute{
+				MarkdownDescription: "Directory containing `supabase/config.toml` of the local project. Only used in local mode. Defaults to the current directory. Can also be set with the `SUPABASE_WORKDIR` environment variable.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of times a request is retried after a rate limit (429), a transient server error (500, 502, 503, 504), or a network error. Only idempotent requests are retried on server and network errors. Waits honor `Retry-After` and otherwise use jittered exponential backoff. Set to `0` to disable retries. Defaults to `4`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 20),
+				},
+			},
+			"oauth_client_id": schema.StringAttribute{
+				MarkdownDescription: "Client ID of a Supabase OAuth application. Together with `oauth_client_secret` and either `oauth_refresh_token_file` or `oauth_refresh_token`, the provider authenticates as the OAuth application instead of with an access token, and refreshes its access token before expiry. Can also be set with the `SUPABASE_OAUTH_CLIENT_ID` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("access_token")),
+					stringvalidator.AlsoRequires(path.MatchRoot("oauth_client_secret")),
+				},
+			},
+			"oauth_client_secret": schema.StringAttribute{
+				MarkdownDescription: "Client secret of the Supabase OAuth application. Can also be set with the `SUPABASE_OAUTH_CLIENT_SECRET` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("oauth_client_id")),
+				},
+			},
+			"oauth_refresh_token": schema.StringAttribute{
+				MarkdownDescription: "Refresh token obtained by authorizing the OAuth application. Supabase rotates refresh tokens, and each exchange invalidates the previous one, so the token only works for a single provider process: `terraform plan` and `terraform apply` each need a fresh one. Use `oauth_refresh_token_file` to keep the rotated token instead. Can also be set with the `SUPABASE_OAUTH_REFRESH_TOKEN` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("oauth_client_id")),
+					stringvalidator.ConflictsWith(path.MatchRoot("oauth_refresh_token_file")),
+				},
+			},
+			"oauth_refresh_token_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a file holding the refresh token of the OAuth application. The provider reads the refresh token from it before each exchange and writes the rotated one back, so that later runs keep working. The file must be writable and must not be shared by concurrent runs. Can also be set with the `SUPABASE_OAUTH_REFRESH_TOKEN_FILE` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("oauth_client_id")),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "Default organization for resources that omit `organization_id`. Can also be set with the `SUPABASE_ORGANIZATION_ID` environment variable.",
+				Optional:            true,
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Name of the profile in `profiles_file` to read the access token and endpoint from. Defaults to the `default` profile when it exists. Can also be set with the `SUPABASE_PROFILE` environment variable.",
+				Optional:            true,
+			},
+			"profiles_file": schema.StringAttribute{
+				MarkdownDescription: "Path of the TOML file with named profiles. Defaults to `~/.supabase/profiles.toml`. Can also be set with the `SUPABASE_PROFILES_FILE` environment variable.",
+				Optional:            true,
+			},
+			"max_requests_per_minute": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of Management API requests per minute, shared by all resources and data sources of the provider configuration. Requests wait for their turn instead of hitting the rate limit of the access token. Set to `0` to disable. Defaults to `120`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"project_ref": schema.StringAttribute{
+				MarkdownDescription: "Default project reference for resources and data sources that omit `project_ref`. Changing it replaces the resources that rely on it. In local mode, defaults to the `project_id` of `supabase/config.toml`. Can also be set with the `SUPABASE_PROJECT_REF` environment variable.",
+				Optional:            true,
+			},
+			"request_timeout": schema.Int64Attribute{
+				MarkdownDescription: "Maximum time in seconds for a single API call, including retries. Defaults to `300`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -64,46 +184,260 @@ func (p *SupabaseProvider) Configure(ctx context.Context, req provider.Configure
 	}
 
 	// Configuration values are now available.
+	if data.AccessToken.IsNull() {
+		data.AccessToken = types.StringValue(os.Getenv("SUPABASE_ACCESS_TOKEN"))
+	}
+	if data.Profile.IsNull() {
+		data.Profile = types.StringValue(os.Getenv("SUPABASE_PROFILE"))
+	}
+	if data.ProfilesFile.IsNull() {
+		data.ProfilesFile = types.StringValue(os.Getenv("SUPABASE_PROFILES_FILE"))
+	}
+	if data.ProfilesFile.ValueString() == "" {
+		data.ProfilesFile = types.StringValue(defaultProfilesFile())
+	}
+	if data.OAuthClientId.IsNull() {
+		data.OAuthClientId = types.StringValue(os.Getenv("SUPABASE_OAUTH_CLIENT_ID"))
+	}
+	if data.OAuthClientSecret.IsNull() {
+		data.OAuthClientSecret = types.StringValue(os.Getenv("SUPABASE_OAUTH_CLIENT_SECRET"))
+	}
+	if data.OAuthRefreshTokenFile.IsNull() {
+		data.OAuthRefreshTokenFile = types.StringValue(os.Getenv("SUPABASE_OAUTH_REFRESH_TOKEN_FILE"))
+	}
+	if data.OAuthRefreshToken.IsNull() && data.OAuthRefreshTokenFile.ValueString() == "" {
+		data.OAuthRefreshToken = types.StringValue(os.Getenv("SUPABASE_OAUTH_REFRESH_TOKEN"))
+	}
+	if data.OAuthRefreshTokenFile.ValueString() != "" {
+		refreshToken, err := readRefreshTokenFile(data.OAuthRefreshTokenFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("oauth_refresh_token_file"),
+				"Unable to Read OAuth Refresh Token",
+				fmt.Sprintf("Unable to read %s: %s", data.OAuthRefreshTokenFile.ValueString(), err),
+			)
+			return
+		}
+		data.OAuthRefreshToken = types.StringValue(refreshToken)
+	}
+
+	useOAuth := data.OAuthClientId.ValueString() != "" || data.OAuthClientSecret.ValueString() != "" || data.OAuthRefreshToken.ValueString() != ""
+	if useOAuth && (data.OAuthClientId.ValueString() == "" || data.OAuthClientSecret.ValueString() == "" || data.OAuthRefreshToken.ValueString() == "") {
+		resp.Diagnostics.AddError(
+			"Incomplete OAuth Credentials",
+			"oauth_client_id, oauth_client_secret, and oauth_refresh_token or oauth_refresh_token_file must all be set to authenticate as an OAuth application.",
+		)
+		return
+	}
+	if data.StorageUrl.IsNull() {
+		data.StorageUrl = types.StringValue(os.Getenv("SUPABASE_STORAGE_URL"))
+	}
+	if data.ServiceRoleKey.IsNull() {
+		data.ServiceRoleKey = types.StringValue(os.Getenv("SUPABASE_SERVICE_ROLE_KEY"))
+	}
+	if data.Mode.IsNull() {
+		data.Mode = types.StringValue(os.Getenv("SUPABASE_MODE"))
+	}
+	if data.Mode.ValueString() == "" {
+		data.Mode = types.StringValue(settings.ModeRemote)
+	}
+	if data.Workdir.IsNull() {
+		data.Workdir = types.StringValue(os.Getenv("SUPABASE_WORKDIR"))
+	}
+	if data.Workdir.ValueString() == "" {
+		data.Workdir = types.StringValue(".")
+	}
+
+	if data.ProjectRef.IsNull() {
+		data.ProjectRef = types.StringValue(os.Getenv("SUPABASE_PROJECT_REF"))
+	}
+	if data.OrganizationId.IsNull() {
+		data.OrganizationId = types.StringValue(os.Getenv("SUPABASE_ORGANIZATION_ID"))
+	}
+	if ref := data.ProjectRef.ValueString(); ref != "" && data.Mode.ValueString() != settings.ModeLocal && !projectRefPattern.MatchString(ref) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("project_ref"),
+			"Invalid Project Reference",
+			fmt.Sprintf("%q is not a project reference. Project references are the 20 lowercase letters in the project URL, for example https://<project_ref>.supabase.co.", ref),
+		)
+	}
+	if data.OrganizationId.ValueString() != "" && strings.TrimSpace(data.OrganizationId.ValueString()) == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("organization_id"),
+			"Invalid Organization ID",
+			"organization_id must not be blank.",
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	localAPIURL := ""
+	localAnonKey := ""
+	if data.Mode.ValueString() == settings.ModeLocal {
+		local, err := loadLocalProject(data.Workdir.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("workdir"),
+				"Unable to Load Local Project",
+				fmt.Sprintf("Local mode reads supabase/config.toml from %s, got error: %s. Run `supabase init` there or set workdir to the directory of the local project.", data.Workdir.ValueString(), err),
+			)
+			return
+		}
+
+		localAPIURL = local.APIURL
+		localAnonKey = local.AnonKey
+		if data.StorageUrl.ValueString() == "" {
+			data.StorageUrl = types.StringValue(local.APIURL)
+		}
+		if data.ServiceRoleKey.ValueString() == "" {
+			data.ServiceRoleKey = types.StringValue(local.ServiceRoleKey)
+		}
+		if data.ProjectRef.ValueString() == "" {
+			data.ProjectRef = types.StringValue(local.ProjectId)
+		}
+	}
+
+	// Fall back to profiles and the login of the Supabase CLI
+	if data.AccessToken.ValueString() == "" && !useOAuth && data.Mode.ValueString() != settings.ModeLocal {
+		credentials, err := discoverCredentials(data.Profile.ValueString(), data.ProfilesFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Missing Supabase Access Token",
+				fmt.Sprintf("Unable to find a Supabase access token: %s. "+
+					"Set access_token in the provider configuration, set the SUPABASE_ACCESS_TOKEN environment variable, "+
+					"add a profile to %s, or run `supabase login`. "+
+					"Access tokens can be created at https://supabase.com/dashboard/account/tokens.", err, data.ProfilesFile.ValueString()),
+			)
+			return
+		}
+
+		tflog.Debug(ctx, "using Supabase access token from "+credentials.Source)
+		data.AccessToken = types.StringValue(credentials.AccessToken)
+		if data.Endpoint.IsNull() && credentials.Endpoint != "" {
+			data.Endpoint = types.StringValue(credentials.Endpoint)
+		}
+	}
 	if data.Endpoint.IsNull() {
 		data.Endpoint = types.StringValue("https://api.supabase.com")
 	}
-	if data.AccessToken.IsNull() {
-		data.AccessToken = types.StringValue(os.Getenv("SUPABASE_ACCESS_TOKEN"))
+
+	maxRetries := defaultMaxRetries
+	if !data.MaxRetries.IsNull() {
+		maxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	requestTimeout := defaultRequestTimeout
+	if !data.RequestTimeout.IsNull() {
+		requestTimeout = time.Duration(data.RequestTimeout.ValueInt64()) * time.Second
 	}
+	maxRequestsPerMinute := defaultMaxRequestsPerMinute
+	if !data.MaxRequestsPerMinute.IsNull() {
+		maxRequestsPerMinute = int(data.MaxRequestsPerMinute.ValueInt64())
+	}
+	var rateLimiter *RateLimiter
+	if maxRequestsPerMinute > 0 {
+		rateLimiter = NewRateLimiter(maxRequestsPerMinute)
+	}
+
+	// Only the Management API is rate limited per access token, so storage
+	// and local requests share the retries but not the limiter.
+	httpClient := newRetryingHTTPClient(maxRetries, requestTimeout, nil)
+	managementHTTPClient := newRetryingHTTPClient(maxRetries, requestTimeout, rateLimiter)
+
+	// Create token manager for automatic token exchange
+	var tokenManager *TokenManager
 
 	// Example client configuration for data sources and resources
-	client, _ := api.NewClientWithResponses(
+	client, err := api.NewClientWithResponses(
 		data.Endpoint.ValueString(),
+		api.WithHTTPClient(managementHTTPClient),
 		api.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
-			req.Header.Set("Authorization", "Bearer "+data.AccessToken.ValueString())
+			if data.Mode.ValueString() == settings.ModeLocal {
+				return errors.New("the Management API is not available in local mode")
+			}
+			token, err := tokenManager.GetManagementToken(ctx)
+			if err != nil {
+				return err
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
 			req.Header.Set("User-Agent", "TFProvider/"+p.version)
 			return nil
 		}),
 	)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoint"),
+			"Unable to Create Supabase API Client",
+			fmt.Sprintf("Unable to create the Management API client for %s: %s", data.Endpoint.ValueString(), err),
+		)
+		return
+	}
 
-	// Create token manager for automatic token exchange
-	tokenManager := NewTokenManager(client, data.AccessToken.ValueString())
+	tokenManager = NewTokenManager(client, data.AccessToken.ValueString())
+
+	if useOAuth {
+		// The token exchange must not carry the bearer token it refreshes
+		oauthClient, err := api.NewClientWithResponses(
+			data.Endpoint.ValueString(),
+			api.WithHTTPClient(managementHTTPClient),
+			api.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+				req.Header.Set("User-Agent", "TFProvider/"+p.version)
+				return nil
+			}),
+		)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("endpoint"),
+				"Unable to Create Supabase API Client",
+				fmt.Sprintf("Unable to create the OAuth client for %s: %s", data.Endpoint.ValueString(), err),
+			)
+			return
+		}
+
+		tokenManager.SetOAuthCredentials(oauthClient, data.OAuthClientId.ValueString(), data.OAuthClientSecret.ValueString(), data.OAuthRefreshToken.ValueString(), data.OAuthRefreshTokenFile.ValueString())
+	}
 
 	// Create provider data with Management API client, access token, and token manager
 	providerData := &settings.SupabaseProviderData{
+		Mode:             data.Mode.ValueString(),
+		LocalAPIURL:      localAPIURL,
+		LocalAnonKey:     localAnonKey,
 		ManagementClient: client,
 		AccessToken:      data.AccessToken.ValueString(),
 		TokenManager:     tokenManager,
+		StorageEndpoints: NewStorageEndpointResolver(client, data.StorageUrl.ValueString()),
+		ServiceRoleKey:   data.ServiceRoleKey.ValueString(),
+		HTTPClient:       httpClient,
+		ProjectRef:       data.ProjectRef.ValueString(),
+		OrganizationId:   data.OrganizationId.ValueString(),
 	}
 
 	resp.DataSourceData = providerData
 	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
 }
 
 func (p *SupabaseProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewProjectResource,
 		NewSettingsResource,
+		NewAuthConfigResource,
+		NewPostgresConfigResource,
+		NewPostgrestConfigResource,
+		NewStorageConfigResource,
+		NewPoolerConfigResource,
 		NewBranchResource,
 		NewEdgeFunctionResource,
 		NewStorageBucketResource,
 		NewSsoProviderResource,
 		NewDatabaseWebhookResource,
+		NewAPIKeyResource,
+		NewJwtSigningKeyResource,
+		NewThirdPartyAuthResource,
+		NewStorageObjectResource,
+		NewStorageDirectorySyncResource,
+		NewStoragePolicyResource,
+		NewNetworkRestrictionsResource,
 	}
 }
 
@@ -114,6 +448,23 @@ func (p *SupabaseProvider) DataSources(ctx context.Context) []func() datasource.
 		NewAPIKeysDataSource,
 		NewStorageBucketsDataSource,
 		NewSsoProvidersDataSource,
+		NewJwtSigningKeysDataSource,
+	}
+}
+
+func (p *SupabaseProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAPIKeysEphemeralResource,
+		NewDatabaseCredentialsEphemeralResource,
+		NewTemporaryDbRoleEphemeralResource,
+	}
+}
+
+func (p *SupabaseProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewConnectionStringFunction,
+		NewProjectURLFunction,
+		NewParseRefFunction,
 	}
 }
 
@@ -1,10 +1,14 @@
 package provider
 
 import (
+	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -15,8 +19,46 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"supabase": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccProtoV6ProviderFactoriesWithEcho adds the echo provider, which copies
+// the values of ephemeral resources into state so that tests can check them.
+var testAccProtoV6ProviderFactoriesWithEcho = map[string]func() (tfprotov6.ProviderServer, error){
+	"supabase": providerserver.NewProtocol6WithError(New("test")()),
+	"echo":     echoprovider.NewProviderServer(),
+}
+
 func testAccPreCheck(t *testing.T) {
 	// You can add code here to run prior to any test case execution, for example assertions
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
+	if os.Getenv("SUPABASE_ACCESS_TOKEN") == "" {
+		// API calls are mocked, but the provider requires a token
+		t.Setenv("SUPABASE_ACCESS_TOKEN", "sbp_0000000000000000000000000000000000000000")
+	}
+}
+
+func TestResolveProjectRef(t *testing.T) {
+	providerData := &settings.SupabaseProviderData{ProjectRef: "mayuaycdtijbctgqbycg"}
+
+	projectRef := types.StringNull()
+	if diags := providerData.ResolveProjectRef(&projectRef); diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+	if projectRef.ValueString() != "mayuaycdtijbctgqbycg" {
+		t.Errorf("Expected provider project_ref, got %s", projectRef)
+	}
+
+	// A configured value takes precedence
+	projectRef = types.StringValue("abcdefghijklmnopqrst")
+	if diags := providerData.ResolveProjectRef(&projectRef); diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+	if projectRef.ValueString() != "abcdefghijklmnopqrst" {
+		t.Errorf("Expected configured project_ref, got %s", projectRef)
+	}
+
+	// Without a default, the data source must set project_ref
+	projectRef = types.StringNull()
+	if diags := (&settings.SupabaseProviderData{}).ResolveProjectRef(&projectRef); !diags.HasError() {
+		t.Errorf("Expected error diagnostic without a default project_ref")
+	}
 }
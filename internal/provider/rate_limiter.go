@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultMaxRequestsPerMinute matches the Management API limit per access token
+const defaultMaxRequestsPerMinute = 120
+
+// RateLimiter is a token bucket shared by every request made with the same
+// provider configuration. Tokens refill continuously, and up to burst tokens
+// can accumulate while the provider is idle.
+type RateLimiter struct {
+	interval time.Duration // Time to refill one token
+	burst    float64
+
+	tokens float64
+	last   time.Time
+	mutex  sync.Mutex
+
+	now   func() time.Time
+	sleep func(ctx context.Context, wait time.Duration) error
+}
+
+// NewRateLimiter creates a rate limiter allowing requestsPerMinute requests
+// per minute on average, and bursts of up to a tenth of that.
+func NewRateLimiter(requestsPerMinute int) *RateLimiter {
+	burst := max(float64(requestsPerMinute/10), 1)
+
+	return &RateLimiter{
+		interval: time.Minute / time.Duration(requestsPerMinute),
+		burst:    burst,
+		tokens:   burst,
+		now:      time.Now,
+		sleep:    sleepContext,
+	}
+}
+
+// Wait blocks until a request can be made, or until ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mutex.Lock()
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = min(l.tokens+float64(now.Sub(l.last))/float64(l.interval), l.burst)
+	}
+	l.last = now
+
+	// Reserve a token, going into debt when none is left so that waiting
+	// requests are served in order.
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens * float64(l.interval))
+	}
+	l.mutex.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+
+	if err := l.sleep(ctx, wait); err != nil {
+		// Give the reservation back
+		l.mutex.Lock()
+		l.tokens++
+		l.mutex.Unlock()
+		return fmt.Errorf("rate limit wait canceled: %w", err)
+	}
+	return nil
+}
+
+// rateLimitTransport waits for the rate limiter before every request,
+// including retries.
+type rateLimitTransport struct {
+	base    http.RoundTripper // Defaults to http.DefaultTransport when nil
+	limiter *RateLimiter
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return base.RoundTrip(req)
+}
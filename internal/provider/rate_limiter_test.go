@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestRateLimiter(requestsPerMinute int, now *time.Time, waits *[]time.Duration) *RateLimiter {
+	limiter := NewRateLimiter(requestsPerMinute)
+	limiter.now = func() time.Time { return *now }
+	limiter.sleep = func(ctx context.Context, wait time.Duration) error {
+		*waits = append(*waits, wait)
+		return nil
+	}
+	return limiter
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var waits []time.Duration
+
+	// 60 requests per minute refill one token per second, with a burst of 6
+	limiter := newTestRateLimiter(60, &now, &waits)
+
+	for i := 0; i < 8; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+
+	// The burst is served immediately, and queued requests wait in order
+	if len(waits) != 2 || waits[0] != time.Second || waits[1] != 2*time.Second {
+		t.Fatalf("Unexpected waits %v", waits)
+	}
+
+	// Tokens refill over time, up to the burst
+	now = now.Add(time.Hour)
+	waits = nil
+	for i := 0; i < 6; i++ {
+		_ = limiter.Wait(context.Background())
+	}
+	if len(waits) != 0 {
+		t.Errorf("Expected a full burst after idling, got waits %v", waits)
+	}
+}
+
+func TestRateLimiterCanceled(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var waits []time.Duration
+
+	limiter := newTestRateLimiter(1, &now, &waits)
+	_ = limiter.Wait(context.Background())
+
+	limiter.sleep = func(ctx context.Context, wait time.Duration) error {
+		return context.Canceled
+	}
+	if err := limiter.Wait(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected canceled error, got %v", err)
+	}
+
+	// The canceled reservation is given back
+	if limiter.tokens != 0 {
+		t.Errorf("Expected the reservation to be released, got %f tokens", limiter.tokens)
+	}
+}
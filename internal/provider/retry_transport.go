@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxRetries     = 4
+	defaultRequestTimeout = 5 * time.Minute
+	retryMinBackoff       = 500 * time.Millisecond
+	retryMaxBackoff       = 30 * time.Second
+	// retryMaxRetryAfter bounds how long a Retry-After header can stall a call
+	retryMaxRetryAfter = 2 * time.Minute
+)
+
+// retryTransport retries requests that failed with a rate limit, a transient
+// server error, or a network error. Idempotent requests are retried on any of
+// those, while other requests are only retried on 429, which guarantees that
+// the request was not processed. Waits follow Retry-After when present, and
+// jittered exponential backoff otherwise.
+type retryTransport struct {
+	base       http.RoundTripper // Defaults to http.DefaultTransport when nil
+	maxRetries int
+	sleep      func(ctx context.Context, wait time.Duration) error
+}
+
+// newRetryingHTTPClient returns an HTTP client that retries failed requests up
+// to maxRetries times, and gives up on a call once timeout elapses. When
+// limiter is non-nil, every attempt waits for it first.
+func newRetryingHTTPClient(maxRetries int, timeout time.Duration, limiter *RateLimiter) *http.Client {
+	transport := &retryTransport{
+		maxRetries: maxRetries,
+		sleep:      sleepContext,
+	}
+	if limiter != nil {
+		transport.base = &rateLimitTransport{limiter: limiter}
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := base.RoundTrip(req)
+
+		if attempt >= t.maxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		// Requests with a body can only be replayed when it can be rewound
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		wait := retryBackoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				wait = min(retryAfter, retryMaxRetryAfter)
+			}
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// shouldRetry reports whether a request is safe and worth retrying.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if !isIdempotent(req.Method) {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryBackoff returns the wait before the next attempt, doubling on every
+// attempt with jitter between half and the full delay.
+func retryBackoff(attempt int) time.Duration {
+	backoff := retryMaxBackoff
+	if attempt < 16 {
+		backoff = min(retryMinBackoff<<attempt, retryMaxBackoff)
+	}
+	return backoff/2 + rand.N(backoff/2)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("giving up on retry: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestRetryClient(maxRetries int, waits *[]time.Duration) *http.Client {
+	return &http.Client{
+		Transport: &retryTransport{
+			base:       http.DefaultTransport,
+			maxRetries: maxRetries,
+			sleep: func(ctx context.Context, wait time.Duration) error {
+				*waits = append(*waits, wait)
+				return nil
+			},
+		},
+	}
+}
+
+func TestRetryTransportRetriesTransientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	resp, err := newTestRetryClient(4, &waits).Get(server.URL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || calls.Load() != 3 {
+		t.Fatalf("Expected success on the third attempt, got %d after %d calls", resp.StatusCode, calls.Load())
+	}
+
+	if len(waits) != 2 || waits[0] != 7*time.Second {
+		t.Fatalf("Expected Retry-After to be honored, got %v", waits)
+	}
+	// Second attempt uses jittered backoff between half and the full delay
+	if waits[1] < retryMinBackoff || waits[1] >= 2*retryMinBackoff {
+		t.Errorf("Unexpected backoff %s", waits[1])
+	}
+}
+
+func TestRetryTransportNonIdempotent(t *testing.T) {
+	var calls atomic.Int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	resp, err := newTestRetryClient(4, &waits).Post(server.URL, "application/json", strings.NewReader(`{"name":"test"}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	// 429 is replayed with the same body, but 503 is not retried for POST
+	if resp.StatusCode != http.StatusServiceUnavailable || calls.Load() != 2 {
+		t.Fatalf("Expected 503 after 2 calls, got %d after %d calls", resp.StatusCode, calls.Load())
+	}
+	if bodies[1] != `{"name":"test"}` {
+		t.Errorf("Expected the body to be replayed, got %q", bodies[1])
+	}
+}
+
+func TestRetryTransportGivesUp(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	resp, err := newTestRetryClient(2, &waits).Get(server.URL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable || calls.Load() != 3 {
+		t.Errorf("Expected last response after 3 calls, got %d after %d calls", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if wait, ok := parseRetryAfter("120", now); !ok || wait != 2*time.Minute {
+		t.Errorf("Unexpected wait for seconds: %s %t", wait, ok)
+	}
+
+	if wait, ok := parseRetryAfter("Mon, 01 Jan 2024 00:00:30 GMT", now); !ok || wait != 30*time.Second {
+		t.Errorf("Unexpected wait for date: %s %t", wait, ok)
+	}
+
+	if _, ok := parseRetryAfter("soon", now); ok {
+		t.Errorf("Expected invalid value to be ignored")
+	}
+}
@@ -2,7 +2,10 @@ package settings
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"reflect"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -50,7 +53,7 @@ func GetAuthSchemaAttributes() map[string]schema.Attribute {
 		attrs[k] = v
 	}
 
-	return attrs
+	return withWriteOnlySecrets(attrs, authWriteOnlySecrets...)
 }
 
 // ReadAuthConfig reads auth configuration from the API and populates the state
@@ -132,45 +135,38 @@ func UpdateAuthConfig(ctx context.Context, client *api.ClientWithResponses, plan
 		return diags
 	}
 
-	// Build the update request body
-	body := api.UpdateAuthConfigBody{}
-
-	// Map Terraform plan to API request body (embedded structs, so access directly)
-	if !plan.Auth.DisableSignup.IsNull() && !plan.Auth.DisableSignup.IsUnknown() {
-		val := plan.Auth.DisableSignup.ValueBool()
-		body.DisableSignup = &val
-	}
-	if !plan.Auth.JwtExp.IsNull() && !plan.Auth.JwtExp.IsUnknown() {
-		val := int(plan.Auth.JwtExp.ValueInt64())
-		body.JwtExp = &val
-	}
-
-	// External providers
-	if !plan.Auth.ExternalGithubEnabled.IsNull() && !plan.Auth.ExternalGithubEnabled.IsUnknown() {
-		val := plan.Auth.ExternalGithubEnabled.ValueBool()
-		body.ExternalGithubEnabled = &val
-	}
-	if !plan.Auth.ExternalGithubClientId.IsNull() && !plan.Auth.ExternalGithubClientId.IsUnknown() {
-		val := plan.Auth.ExternalGithubClientId.ValueString()
-		body.ExternalGithubClientId = &val
+	// Build the update request body from the attributes, which are named
+	// after the fields of the API
+	body, err := authConfigBody(plan.Auth)
+	if err != nil {
+		diags.AddError(
+			"Error Updating Auth Config",
+			"Could not encode auth config, unexpected error: "+err.Error(),
+		)
+		return diags
 	}
 
-	// Handle nested ExternalGithub configuration
-	if plan.Auth.ExternalGithub != nil {
-		if !plan.Auth.ExternalGithub.Enabled.IsNull() && !plan.Auth.ExternalGithub.Enabled.IsUnknown() {
-			val := plan.Auth.ExternalGithub.Enabled.ValueBool()
-			body.ExternalGithubEnabled = &val
-		}
-		if !plan.Auth.ExternalGithub.ClientId.IsNull() && !plan.Auth.ExternalGithub.ClientId.IsUnknown() {
-			val := plan.Auth.ExternalGithub.ClientId.ValueString()
-			body.ExternalGithubClientId = &val
+	// Secrets of the nested external providers
+	secrets := externalProviderSecrets(&body)
+	for i, provider := range plan.Auth.externalProviders() {
+		if provider == nil {
+			continue
 		}
-		if !plan.Auth.ExternalGithub.Secret.IsNull() && !plan.Auth.ExternalGithub.Secret.IsUnknown() {
-			val := plan.Auth.ExternalGithub.Secret.ValueString()
-			body.ExternalGithubSecret = &val
+		if val := secretValue(provider.Secret, provider.SecretWo); val != nil {
+			*secrets[i] = val
 		}
 	}
 
+	// Secrets, from their write-only variants when those are set
+	body.SmtpPass = secretValue(plan.Auth.SmtpPass, plan.Auth.SmtpPassWo)
+	body.SmsTwilioAuthToken = secretValue(plan.Auth.SmsTwilioAuthToken, plan.Auth.SmsTwilioAuthTokenWo)
+	body.SecurityCaptchaSecret = secretValue(plan.Auth.SecurityCaptchaSecret, plan.Auth.SecurityCaptchaSecretWo)
+	body.HookCustomAccessTokenSecrets = secretValue(plan.Auth.HookCustomAccessTokenSecrets, plan.Auth.HookCustomAccessTokenSecretsWo)
+	body.HookMfaVerificationAttemptSecrets = secretValue(plan.Auth.HookMfaVerificationAttemptSecrets, plan.Auth.HookMfaVerificationAttemptSecretsWo)
+	body.HookPasswordVerificationAttemptSecrets = secretValue(plan.Auth.HookPasswordVerificationAttemptSecrets, plan.Auth.HookPasswordVerificationAttemptSecretsWo)
+	body.HookSendEmailSecrets = secretValue(plan.Auth.HookSendEmailSecrets, plan.Auth.HookSendEmailSecretsWo)
+	body.HookSendSmsSecrets = secretValue(plan.Auth.HookSendSmsSecrets, plan.Auth.HookSendSmsSecretsWo)
+
 	resp, err := client.V1UpdateAuthServiceConfigWithResponse(ctx, plan.ProjectRef.ValueString(), body)
 	if err != nil {
 		diags.AddError(
@@ -191,4 +187,54 @@ func UpdateAuthConfig(ctx context.Context, client *api.ClientWithResponses, plan
 	tflog.Trace(ctx, "Updated auth config via API")
 
 	return diags
-}
\ No newline at end of file
+}
+
+// authConfigBody returns the update body of the known attributes of auth. The
+// attributes of a nested external provider map onto the fields prefixed with
+// its name, and take precedence over its direct attributes.
+func authConfigBody(auth *AuthConfig) (api.UpdateAuthConfigBody, error) {
+	values := map[string]any{}
+	nested := map[string]any{}
+	addAuthConfigValues(values, nested, "", reflect.ValueOf(*auth))
+	maps.Copy(values, nested)
+
+	var body api.UpdateAuthConfigBody
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return body, err
+	}
+	// Attributes without a field in the body, such as write-only variants,
+	// are ignored
+	err = json.Unmarshal(encoded, &body)
+	return body, err
+}
+
+func addAuthConfigValues(values map[string]any, nested map[string]any, prefix string, config reflect.Value) {
+	for i := 0; i < config.NumField(); i++ {
+		field := config.Type().Field(i)
+		if field.Anonymous {
+			addAuthConfigValues(values, nested, prefix, config.Field(i))
+			continue
+		}
+		name := prefix + field.Tag.Get("tfsdk")
+
+		switch value := config.Field(i).Interface().(type) {
+		case types.String:
+			if !value.IsNull() && !value.IsUnknown() {
+				values[name] = value.ValueString()
+			}
+		case types.Int64:
+			if !value.IsNull() && !value.IsUnknown() {
+				values[name] = value.ValueInt64()
+			}
+		case types.Bool:
+			if !value.IsNull() && !value.IsUnknown() {
+				values[name] = value.ValueBool()
+			}
+		case *ExternalProviderConfig:
+			if value != nil {
+				addAuthConfigValues(nested, nested, name+"_", reflect.ValueOf(*value))
+			}
+		}
+	}
+}
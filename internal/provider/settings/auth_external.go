@@ -53,13 +53,15 @@ type ExternalProviderConfig struct {
 	Enabled             types.Bool   `tfsdk:"enabled"`
 	ClientId            types.String `tfsdk:"client_id"`
 	Secret              types.String `tfsdk:"secret"`
+	SecretWo            types.String `tfsdk:"secret_wo"`
+	SecretWoVersion     types.Int64  `tfsdk:"secret_wo_version"`
 	RedirectUri         types.String `tfsdk:"redirect_uri"`
 	Url                 types.String `tfsdk:"url"`
 	AdditionalClientIds types.String `tfsdk:"additional_client_ids"`
 }
 
 func GetExternalProviderSchemaAttributes(providerName string) map[string]schema.Attribute {
-	return map[string]schema.Attribute{
+	attrs := map[string]schema.Attribute{
 		"enabled": schema.BoolAttribute{
 			MarkdownDescription: "Enable " + providerName + " provider",
 			Optional:            true,
@@ -87,6 +89,8 @@ func GetExternalProviderSchemaAttributes(providerName string) map[string]schema.
 			Optional:            true,
 		},
 	}
+
+	return withWriteOnlySecrets(attrs, "secret")
 }
 
 func GetAuthExternalSchemaAttributes() map[string]schema.Attribute {
@@ -8,21 +8,31 @@ import (
 // AuthHooksConfig represents webhook and hook configuration
 type AuthHooksConfig struct {
 	// Hook/Webhook settings
-	HookCustomAccessTokenEnabled           types.Bool   `tfsdk:"hook_custom_access_token_enabled"`
-	HookCustomAccessTokenSecrets           types.String `tfsdk:"hook_custom_access_token_secrets"`
-	HookCustomAccessTokenUri               types.String `tfsdk:"hook_custom_access_token_uri"`
-	HookMfaVerificationAttemptEnabled      types.Bool   `tfsdk:"hook_mfa_verification_attempt_enabled"`
-	HookMfaVerificationAttemptSecrets      types.String `tfsdk:"hook_mfa_verification_attempt_secrets"`
-	HookMfaVerificationAttemptUri          types.String `tfsdk:"hook_mfa_verification_attempt_uri"`
-	HookPasswordVerificationAttemptEnabled types.Bool   `tfsdk:"hook_password_verification_attempt_enabled"`
-	HookPasswordVerificationAttemptSecrets types.String `tfsdk:"hook_password_verification_attempt_secrets"`
-	HookPasswordVerificationAttemptUri     types.String `tfsdk:"hook_password_verification_attempt_uri"`
-	HookSendEmailEnabled                   types.Bool   `tfsdk:"hook_send_email_enabled"`
-	HookSendEmailSecrets                   types.String `tfsdk:"hook_send_email_secrets"`
-	HookSendEmailUri                       types.String `tfsdk:"hook_send_email_uri"`
-	HookSendSmsEnabled                     types.Bool   `tfsdk:"hook_send_sms_enabled"`
-	HookSendSmsSecrets                     types.String `tfsdk:"hook_send_sms_secrets"`
-	HookSendSmsUri                         types.String `tfsdk:"hook_send_sms_uri"`
+	HookCustomAccessTokenEnabled                    types.Bool   `tfsdk:"hook_custom_access_token_enabled"`
+	HookCustomAccessTokenSecrets                    types.String `tfsdk:"hook_custom_access_token_secrets"`
+	HookCustomAccessTokenSecretsWo                  types.String `tfsdk:"hook_custom_access_token_secrets_wo"`
+	HookCustomAccessTokenSecretsWoVersion           types.Int64  `tfsdk:"hook_custom_access_token_secrets_wo_version"`
+	HookCustomAccessTokenUri                        types.String `tfsdk:"hook_custom_access_token_uri"`
+	HookMfaVerificationAttemptEnabled               types.Bool   `tfsdk:"hook_mfa_verification_attempt_enabled"`
+	HookMfaVerificationAttemptSecrets               types.String `tfsdk:"hook_mfa_verification_attempt_secrets"`
+	HookMfaVerificationAttemptSecretsWo             types.String `tfsdk:"hook_mfa_verification_attempt_secrets_wo"`
+	HookMfaVerificationAttemptSecretsWoVersion      types.Int64  `tfsdk:"hook_mfa_verification_attempt_secrets_wo_version"`
+	HookMfaVerificationAttemptUri                   types.String `tfsdk:"hook_mfa_verification_attempt_uri"`
+	HookPasswordVerificationAttemptEnabled          types.Bool   `tfsdk:"hook_password_verification_attempt_enabled"`
+	HookPasswordVerificationAttemptSecrets          types.String `tfsdk:"hook_password_verification_attempt_secrets"`
+	HookPasswordVerificationAttemptSecretsWo        types.String `tfsdk:"hook_password_verification_attempt_secrets_wo"`
+	HookPasswordVerificationAttemptSecretsWoVersion types.Int64  `tfsdk:"hook_password_verification_attempt_secrets_wo_version"`
+	HookPasswordVerificationAttemptUri              types.String `tfsdk:"hook_password_verification_attempt_uri"`
+	HookSendEmailEnabled                            types.Bool   `tfsdk:"hook_send_email_enabled"`
+	HookSendEmailSecrets                            types.String `tfsdk:"hook_send_email_secrets"`
+	HookSendEmailSecretsWo                          types.String `tfsdk:"hook_send_email_secrets_wo"`
+	HookSendEmailSecretsWoVersion                   types.Int64  `tfsdk:"hook_send_email_secrets_wo_version"`
+	HookSendEmailUri                                types.String `tfsdk:"hook_send_email_uri"`
+	HookSendSmsEnabled                              types.Bool   `tfsdk:"hook_send_sms_enabled"`
+	HookSendSmsSecrets                              types.String `tfsdk:"hook_send_sms_secrets"`
+	HookSendSmsSecretsWo                            types.String `tfsdk:"hook_send_sms_secrets_wo"`
+	HookSendSmsSecretsWoVersion                     types.Int64  `tfsdk:"hook_send_sms_secrets_wo_version"`
+	HookSendSmsUri                                  types.String `tfsdk:"hook_send_sms_uri"`
 }
 
 func GetAuthHooksSchemaAttributes() map[string]schema.Attribute {
@@ -15,13 +15,15 @@ type AuthMailerConfig struct {
 	MailerOtpLength                   types.Int64 `tfsdk:"mailer_otp_length"`
 
 	// SMTP settings
-	SmtpAdminEmail   types.String `tfsdk:"smtp_admin_email"`
-	SmtpHost         types.String `tfsdk:"smtp_host"`
-	SmtpMaxFrequency types.Int64  `tfsdk:"smtp_max_frequency"`
-	SmtpPort         types.Int64  `tfsdk:"smtp_port"`
-	SmtpSenderName   types.String `tfsdk:"smtp_sender_name"`
-	SmtpUser         types.String `tfsdk:"smtp_user"`
-	SmtpPass         types.String `tfsdk:"smtp_pass"`
+	SmtpAdminEmail    types.String `tfsdk:"smtp_admin_email"`
+	SmtpHost          types.String `tfsdk:"smtp_host"`
+	SmtpMaxFrequency  types.Int64  `tfsdk:"smtp_max_frequency"`
+	SmtpPort          types.Int64  `tfsdk:"smtp_port"`
+	SmtpSenderName    types.String `tfsdk:"smtp_sender_name"`
+	SmtpUser          types.String `tfsdk:"smtp_user"`
+	SmtpPass          types.String `tfsdk:"smtp_pass"`
+	SmtpPassWo        types.String `tfsdk:"smtp_pass_wo"`
+	SmtpPassWoVersion types.Int64  `tfsdk:"smtp_pass_wo_version"`
 
 	// Mailer templates and subjects
 	MailerSubjectsConfirmation             types.String `tfsdk:"mailer_subjects_confirmation"`
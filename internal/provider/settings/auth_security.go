@@ -8,9 +8,11 @@ import (
 // AuthSecurityConfig represents security and rate limiting settings
 type AuthSecurityConfig struct {
 	// Security settings
-	SecurityCaptchaEnabled  types.Bool   `tfsdk:"security_captcha_enabled"`
-	SecurityCaptchaProvider types.String `tfsdk:"security_captcha_provider"`
-	SecurityCaptchaSecret   types.String `tfsdk:"security_captcha_secret"`
+	SecurityCaptchaEnabled         types.Bool   `tfsdk:"security_captcha_enabled"`
+	SecurityCaptchaProvider        types.String `tfsdk:"security_captcha_provider"`
+	SecurityCaptchaSecret          types.String `tfsdk:"security_captcha_secret"`
+	SecurityCaptchaSecretWo        types.String `tfsdk:"security_captcha_secret_wo"`
+	SecurityCaptchaSecretWoVersion types.Int64  `tfsdk:"security_captcha_secret_wo_version"`
 
 	// Rate limiting settings
 	RateLimitAnonymousUsers types.Int64 `tfsdk:"rate_limit_anonymous_users"`
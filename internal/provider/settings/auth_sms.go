@@ -26,6 +26,8 @@ type AuthSmsConfig struct {
 	SmsTextlocalSender               types.String `tfsdk:"sms_textlocal_sender"`
 	SmsTwilioAccountSid              types.String `tfsdk:"sms_twilio_account_sid"`
 	SmsTwilioAuthToken               types.String `tfsdk:"sms_twilio_auth_token"`
+	SmsTwilioAuthTokenWo             types.String `tfsdk:"sms_twilio_auth_token_wo"`
+	SmsTwilioAuthTokenWoVersion      types.Int64  `tfsdk:"sms_twilio_auth_token_wo_version"`
 	SmsTwilioContentSid              types.String `tfsdk:"sms_twilio_content_sid"`
 	SmsTwilioMessageServiceSid       types.String `tfsdk:"sms_twilio_message_service_sid"`
 	SmsTwilioVerifyAccountSid        types.String `tfsdk:"sms_twilio_verify_account_sid"`
@@ -0,0 +1,188 @@
+package settings
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/supabase/cli/pkg/api"
+)
+
+// writeOnlyVersionSuffix ends the name of the attribute versioning a
+// write-only secret. Changing the version sends the secret again.
+const writeOnlyVersionSuffix = "_wo_version"
+
+// authWriteOnlySecrets are the auth secrets that have write-only variants.
+var authWriteOnlySecrets = []string{
+	"smtp_pass",
+	"sms_twilio_auth_token",
+	"security_captcha_secret",
+	"hook_custom_access_token_secrets",
+	"hook_mfa_verification_attempt_secrets",
+	"hook_password_verification_attempt_secrets",
+	"hook_send_email_secrets",
+	"hook_send_sms_secrets",
+}
+
+// writeOnlySecretAttributes returns the write-only variant of a secret
+// attribute, which Terraform 1.11 and later never store in state or plan,
+// and the version attribute that triggers sending it.
+func writeOnlySecretAttributes(name string, description string) map[string]schema.Attribute {
+	writeOnly := name + "_wo"
+	version := name + writeOnlyVersionSuffix
+
+	return map[string]schema.Attribute{
+		writeOnly: schema.StringAttribute{
+			MarkdownDescription: description + ", as a write-only attribute that is never stored in state. Requires Terraform 1.11 or later. " +
+				"It is sent on create and whenever `" + version + "` changes.",
+			Optional:  true,
+			Sensitive: true,
+			WriteOnly: true,
+			Validators: []validator.String{
+				stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName(name)),
+				stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName(version)),
+			},
+		},
+		version: schema.Int64Attribute{
+			MarkdownDescription: "Version of `" + writeOnly + "`. Change it to send a new value of `" + writeOnly + "`.",
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName(writeOnly)),
+			},
+		},
+	}
+}
+
+// withWriteOnlySecrets adds the write-only variants of the given secrets to
+// attributes.
+func withWriteOnlySecrets(attributes map[string]schema.Attribute, secrets ...string) map[string]schema.Attribute {
+	for _, name := range secrets {
+		for k, v := range writeOnlySecretAttributes(name, attributes[name].GetMarkdownDescription()) {
+			attributes[k] = v
+		}
+	}
+	return attributes
+}
+
+// secretValue returns the value of a secret to send, preferring its
+// write-only variant.
+func secretValue(value types.String, writeOnly types.String) *string {
+	if !writeOnly.IsNull() && !writeOnly.IsUnknown() {
+		return writeOnly.ValueStringPointer()
+	}
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueStringPointer()
+	}
+	return nil
+}
+
+// writeOnlyValue returns the write-only value of a secret from the
+// configuration when it has to be sent, which is when there is no prior
+// state or when its version changed, and null otherwise.
+func writeOnlyValue(value types.String, version types.Int64, priorVersion types.Int64, hasPrior bool) types.String {
+	if hasPrior && version.Equal(priorVersion) {
+		return types.StringNull()
+	}
+	return value
+}
+
+// setWriteOnly copies the write-only secrets that have to be sent from
+// config, since they are never part of the plan. prior is the prior state,
+// or nil on create.
+func (m *SettingsResourceModel) setWriteOnly(config *SettingsResourceModel, prior *SettingsResourceModel) {
+	if m.Auth == nil || config.Auth == nil {
+		return
+	}
+
+	var priorAuth *AuthConfig
+	if prior != nil {
+		priorAuth = prior.Auth
+	}
+	m.Auth.setWriteOnly(config.Auth, priorAuth)
+}
+
+func (c *AuthConfig) setWriteOnly(config *AuthConfig, prior *AuthConfig) {
+	hasPrior := prior != nil
+	if !hasPrior {
+		prior = &AuthConfig{}
+	}
+
+	c.SmtpPassWo = writeOnlyValue(config.SmtpPassWo, config.SmtpPassWoVersion, prior.SmtpPassWoVersion, hasPrior)
+	c.SmsTwilioAuthTokenWo = writeOnlyValue(config.SmsTwilioAuthTokenWo, config.SmsTwilioAuthTokenWoVersion, prior.SmsTwilioAuthTokenWoVersion, hasPrior)
+	c.SecurityCaptchaSecretWo = writeOnlyValue(config.SecurityCaptchaSecretWo, config.SecurityCaptchaSecretWoVersion, prior.SecurityCaptchaSecretWoVersion, hasPrior)
+	c.HookCustomAccessTokenSecretsWo = writeOnlyValue(config.HookCustomAccessTokenSecretsWo, config.HookCustomAccessTokenSecretsWoVersion, prior.HookCustomAccessTokenSecretsWoVersion, hasPrior)
+	c.HookMfaVerificationAttemptSecretsWo = writeOnlyValue(config.HookMfaVerificationAttemptSecretsWo, config.HookMfaVerificationAttemptSecretsWoVersion, prior.HookMfaVerificationAttemptSecretsWoVersion, hasPrior)
+	c.HookPasswordVerificationAttemptSecretsWo = writeOnlyValue(config.HookPasswordVerificationAttemptSecretsWo, config.HookPasswordVerificationAttemptSecretsWoVersion, prior.HookPasswordVerificationAttemptSecretsWoVersion, hasPrior)
+	c.HookSendEmailSecretsWo = writeOnlyValue(config.HookSendEmailSecretsWo, config.HookSendEmailSecretsWoVersion, prior.HookSendEmailSecretsWoVersion, hasPrior)
+	c.HookSendSmsSecretsWo = writeOnlyValue(config.HookSendSmsSecretsWo, config.HookSendSmsSecretsWoVersion, prior.HookSendSmsSecretsWoVersion, hasPrior)
+
+	configProviders := config.externalProviders()
+	priorProviders := prior.externalProviders()
+	for i, provider := range c.externalProviders() {
+		if provider == nil || configProviders[i] == nil {
+			continue
+		}
+		// A provider added to an existing configuration has no prior version
+		priorProvider := priorProviders[i]
+		if priorProvider == nil {
+			priorProvider = &ExternalProviderConfig{SecretWoVersion: types.Int64Unknown()}
+		}
+		provider.SecretWo = writeOnlyValue(configProviders[i].SecretWo, configProviders[i].SecretWoVersion, priorProvider.SecretWoVersion, hasPrior)
+	}
+}
+
+// externalProviders returns the nested configurations of the external
+// providers, in a fixed order.
+func (c *AuthExternalConfig) externalProviders() []*ExternalProviderConfig {
+	return []*ExternalProviderConfig{
+		c.ExternalApple,
+		c.ExternalAzure,
+		c.ExternalBitbucket,
+		c.ExternalDiscord,
+		c.ExternalFacebook,
+		c.ExternalFigma,
+		c.ExternalGithub,
+		c.ExternalGitlab,
+		c.ExternalGoogle,
+		c.ExternalKakao,
+		c.ExternalKeycloak,
+		c.ExternalLinkedinOidc,
+		c.ExternalNotion,
+		c.ExternalSlack,
+		c.ExternalSlackOidc,
+		c.ExternalSpotify,
+		c.ExternalTwitch,
+		c.ExternalTwitter,
+		c.ExternalWorkos,
+		c.ExternalZoom,
+	}
+}
+
+// externalProviderSecrets returns the secret fields of the external providers
+// in body, in the order of externalProviders.
+func externalProviderSecrets(body *api.UpdateAuthConfigBody) []**string {
+	return []**string{
+		&body.ExternalAppleSecret,
+		&body.ExternalAzureSecret,
+		&body.ExternalBitbucketSecret,
+		&body.ExternalDiscordSecret,
+		&body.ExternalFacebookSecret,
+		&body.ExternalFigmaSecret,
+		&body.ExternalGithubSecret,
+		&body.ExternalGitlabSecret,
+		&body.ExternalGoogleSecret,
+		&body.ExternalKakaoSecret,
+		&body.ExternalKeycloakSecret,
+		&body.ExternalLinkedinOidcSecret,
+		&body.ExternalNotionSecret,
+		&body.ExternalSlackSecret,
+		&body.ExternalSlackOidcSecret,
+		&body.ExternalSpotifySecret,
+		&body.ExternalTwitchSecret,
+		&body.ExternalTwitterSecret,
+		&body.ExternalWorkosSecret,
+		&body.ExternalZoomSecret,
+	}
+}
@@ -0,0 +1,513 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/supabase/cli/pkg/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &ConfigResource{}
+	_ resource.ResourceWithConfigure   = &ConfigResource{}
+	_ resource.ResourceWithImportState = &ConfigResource{}
+	_ resource.ResourceWithModifyPlan  = &ConfigResource{}
+	_ resource.ResourceWithMoveState   = &ConfigResource{}
+)
+
+// configSection describes a section of supabase_settings that is managed by
+// a resource of its own.
+type configSection struct {
+	typeName    string // Type name without the provider prefix
+	attribute   string // Attribute of the section in supabase_settings
+	description string
+	attributes  func() map[string]schema.Attribute
+	newModel    func() configSectionModel
+	read        func(ctx context.Context, client *api.ClientWithResponses, state *SettingsResourceModel) diag.Diagnostics
+	update      func(ctx context.Context, client *api.ClientWithResponses, plan *SettingsResourceModel) diag.Diagnostics
+}
+
+// configSectionModel is the model of a resource managing a section, which
+// converts to and from the supabase_settings model the Read*Config and
+// Update*Config functions work on.
+type configSectionModel interface {
+	toSettings() *SettingsResourceModel
+	fromSettings(settings *SettingsResourceModel)
+}
+
+func NewAuthConfigResource() resource.Resource {
+	return &ConfigResource{section: configSection{
+		typeName:    "auth_config",
+		attribute:   "auth",
+		description: "Auth settings as [structured configuration](https://api.supabase.com/api/v1#/v1-update-auth-service-config)",
+		attributes:  GetAuthSchemaAttributes,
+		newModel:    func() configSectionModel { return &AuthConfigResourceModel{} },
+		read:        ReadAuthConfig,
+		update:      UpdateAuthConfig,
+	}}
+}
+
+func NewPostgresConfigResource() resource.Resource {
+	return &ConfigResource{section: configSection{
+		typeName:    "postgres_config",
+		attribute:   "database",
+		description: "Postgres settings as [structured configuration](https://api.supabase.com/api/v1#/v1-update-postgres-config)",
+		attributes:  GetDatabaseSchemaAttributes,
+		newModel:    func() configSectionModel { return &PostgresConfigResourceModel{} },
+		read:        ReadDatabaseConfig,
+		update:      UpdateDatabaseConfig,
+	}}
+}
+
+func NewPostgrestConfigResource() resource.Resource {
+	return &ConfigResource{section: configSection{
+		typeName:    "postgrest_config",
+		attribute:   "api",
+		description: "PostgREST settings as [structured configuration](https://api.supabase.com/api/v1#/v1-update-postgrest-service-config)",
+		attributes:  GetApiSchemaAttributes,
+		newModel:    func() configSectionModel { return &PostgrestConfigResourceModel{} },
+		read:        ReadApiConfig,
+		update:      UpdateApiConfig,
+	}}
+}
+
+func NewStorageConfigResource() resource.Resource {
+	return &ConfigResource{section: configSection{
+		typeName:    "storage_config",
+		attribute:   "storage",
+		description: "Storage settings",
+		attributes:  GetStorageSchemaAttributes,
+		newModel:    func() configSectionModel { return &StorageConfigResourceModel{} },
+		read:        ReadStorageConfig,
+		update:      UpdateStorageConfig,
+	}}
+}
+
+func NewPoolerConfigResource() resource.Resource {
+	return &ConfigResource{section: configSection{
+		typeName:    "pooler_config",
+		attribute:   "pooler",
+		description: "Connection pooler settings",
+		attributes:  GetPoolerSchemaAttributes,
+		newModel:    func() configSectionModel { return &PoolerConfigResourceModel{} },
+		read:        ReadPoolerConfig,
+		update:      UpdatePoolerConfig,
+	}}
+}
+
+// AuthConfigResourceModel describes the supabase_auth_config data model.
+type AuthConfigResourceModel struct {
+	ProjectRef     types.String `tfsdk:"project_ref"`
+	Id             types.String `tfsdk:"id"`
+	Effective      types.Object `tfsdk:"effective"`
+	ResetOnDestroy types.Bool   `tfsdk:"reset_on_destroy"`
+	AuthConfig
+}
+
+func (m *AuthConfigResourceModel) toSettings() *SettingsResourceModel {
+	section := m.AuthConfig
+	return &SettingsResourceModel{ProjectRef: m.ProjectRef, Id: m.Id, Effective: m.Effective, ResetOnDestroy: m.ResetOnDestroy, Auth: &section}
+}
+
+func (m *AuthConfigResourceModel) fromSettings(settings *SettingsResourceModel) {
+	m.ProjectRef, m.Id, m.Effective, m.ResetOnDestroy = settings.ProjectRef, settings.Id, settings.Effective, settings.ResetOnDestroy
+	if settings.Auth != nil {
+		m.AuthConfig = *settings.Auth
+	}
+}
+
+// PostgresConfigResourceModel describes the supabase_postgres_config data model.
+type PostgresConfigResourceModel struct {
+	ProjectRef     types.String `tfsdk:"project_ref"`
+	Id             types.String `tfsdk:"id"`
+	Effective      types.Object `tfsdk:"effective"`
+	ResetOnDestroy types.Bool   `tfsdk:"reset_on_destroy"`
+	DatabaseConfig
+}
+
+func (m *PostgresConfigResourceModel) toSettings() *SettingsResourceModel {
+	section := m.DatabaseConfig
+	return &SettingsResourceModel{ProjectRef: m.ProjectRef, Id: m.Id, Effective: m.Effective, ResetOnDestroy: m.ResetOnDestroy, Database: &section}
+}
+
+func (m *PostgresConfigResourceModel) fromSettings(settings *SettingsResourceModel) {
+	m.ProjectRef, m.Id, m.Effective, m.ResetOnDestroy = settings.ProjectRef, settings.Id, settings.Effective, settings.ResetOnDestroy
+	if settings.Database != nil {
+		m.DatabaseConfig = *settings.Database
+	}
+}
+
+// PostgrestConfigResourceModel describes the supabase_postgrest_config data model.
+type PostgrestConfigResourceModel struct {
+	ProjectRef     types.String `tfsdk:"project_ref"`
+	Id             types.String `tfsdk:"id"`
+	Effective      types.Object `tfsdk:"effective"`
+	ResetOnDestroy types.Bool   `tfsdk:"reset_on_destroy"`
+	ApiConfig
+}
+
+func (m *PostgrestConfigResourceModel) toSettings() *SettingsResourceModel {
+	section := m.ApiConfig
+	return &SettingsResourceModel{ProjectRef: m.ProjectRef, Id: m.Id, Effective: m.Effective, ResetOnDestroy: m.ResetOnDestroy, Api: &section}
+}
+
+func (m *PostgrestConfigResourceModel) fromSettings(settings *SettingsResourceModel) {
+	m.ProjectRef, m.Id, m.Effective, m.ResetOnDestroy = settings.ProjectRef, settings.Id, settings.Effective, settings.ResetOnDestroy
+	if settings.Api != nil {
+		m.ApiConfig = *settings.Api
+	}
+}
+
+// StorageConfigResourceModel describes the supabase_storage_config data model.
+type StorageConfigResourceModel struct {
+	ProjectRef     types.String `tfsdk:"project_ref"`
+	Id             types.String `tfsdk:"id"`
+	Effective      types.Object `tfsdk:"effective"`
+	ResetOnDestroy types.Bool   `tfsdk:"reset_on_destroy"`
+	StorageConfig
+}
+
+func (m *StorageConfigResourceModel) toSettings() *SettingsResourceModel {
+	section := m.StorageConfig
+	return &SettingsResourceModel{ProjectRef: m.ProjectRef, Id: m.Id, Effective: m.Effective, ResetOnDestroy: m.ResetOnDestroy, Storage: &section}
+}
+
+func (m *StorageConfigResourceModel) fromSettings(settings *SettingsResourceModel) {
+	m.ProjectRef, m.Id, m.Effective, m.ResetOnDestroy = settings.ProjectRef, settings.Id, settings.Effective, settings.ResetOnDestroy
+	if settings.Storage != nil {
+		m.StorageConfig = *settings.Storage
+	}
+}
+
+// PoolerConfigResourceModel describes the supabase_pooler_config data model.
+type PoolerConfigResourceModel struct {
+	ProjectRef     types.String `tfsdk:"project_ref"`
+	Id             types.String `tfsdk:"id"`
+	Effective      types.Object `tfsdk:"effective"`
+	ResetOnDestroy types.Bool   `tfsdk:"reset_on_destroy"`
+	PoolerConfig
+}
+
+func (m *PoolerConfigResourceModel) toSettings() *SettingsResourceModel {
+	section := m.PoolerConfig
+	return &SettingsResourceModel{ProjectRef: m.ProjectRef, Id: m.Id, Effective: m.Effective, ResetOnDestroy: m.ResetOnDestroy, Pooler: &section}
+}
+
+func (m *PoolerConfigResourceModel) fromSettings(settings *SettingsResourceModel) {
+	m.ProjectRef, m.Id, m.Effective, m.ResetOnDestroy = settings.ProjectRef, settings.Id, settings.Effective, settings.ResetOnDestroy
+	if settings.Pooler != nil {
+		m.PoolerConfig = *settings.Pooler
+	}
+}
+
+// ConfigResource manages one section of the project settings, so that a
+// failure in one section does not block the others and each section can be
+// owned separately.
+type ConfigResource struct {
+	section      configSection
+	client       *api.ClientWithResponses
+	providerData *SupabaseProviderData
+}
+
+func (r *ConfigResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.section.typeName
+}
+
+func (r *ConfigResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := r.section.attributes()
+	attributes[effectiveAttributeName] = effectiveAttribute(r.section.attributes())
+	attributes["project_ref"] = schema.StringAttribute{
+		MarkdownDescription: "Project reference ID. Defaults to the provider `project_ref`.",
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "Project identifier",
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	attributes["reset_on_destroy"] = schema.BoolAttribute{
+		MarkdownDescription: "Restore the documented defaults of the settings when the resource is destroyed or a setting is removed from the configuration. Settings without a known default, such as those that depend on the compute size, are left in place with a warning. Defaults to `false`.",
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(false),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(
+			"%s. Replaces the `%s` attribute of `supabase_settings`. "+
+				"A `moved` block can move the state of a `supabase_settings` resource here, but Terraform allows a single `moved` block per source address, so the state can only be moved to one of the resources split from it. Import the others with the project reference. "+
+				"Only the settings set in the configuration are managed and refreshed, the `effective` attribute shows all of them as read from the API. "+
+				"Destroying the resource leaves the settings in place unless `reset_on_destroy` is set.",
+			r.section.description, r.section.attribute,
+		),
+		Attributes: attributes,
+	}
+}
+
+func (r *ConfigResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*SupabaseProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *SupabaseProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(providerData.RequireManagementAPI("supabase_" + r.section.typeName)...)
+	r.client = providerData.ManagementClient
+	r.providerData = providerData
+}
+
+// ModifyPlan falls back to the provider project_ref when the resource omits it.
+func (r *ConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.PlanProjectRef(ctx, req, resp)
+}
+
+func (r *ConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	data := r.section.newModel()
+	config := r.section.newModel()
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings := data.toSettings()
+	settings.Id = settings.ProjectRef
+	settings.setWriteOnly(config.toSettings(), nil)
+
+	resp.Diagnostics.Append(r.section.update(ctx, r.client, settings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.fromSettings(settings)
+
+	tflog.Trace(ctx, "created "+r.section.typeName)
+	resp.Diagnostics.Append(setAppliedState(ctx, &resp.State, data, req.Plan.Raw)...)
+}
+
+func (r *ConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Skipped resources keep their prior state
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
+	data := r.section.newModel()
+
+	resp.Diagnostics.Append(req.State.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings := data.toSettings()
+	resp.Diagnostics.Append(r.section.read(ctx, r.client, settings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.fromSettings(settings)
+	resp.Diagnostics.Append(setRefreshedState(ctx, &resp.State, data, req.State.Raw)...)
+}
+
+func (r *ConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	data := r.section.newModel()
+	config := r.section.newModel()
+	prior := r.section.newModel()
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, config)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings := data.toSettings()
+	settings.setWriteOnly(config.toSettings(), prior.toSettings())
+	resp.Diagnostics.Append(r.section.update(ctx, r.client, settings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if settings.ResetOnDestroy.ValueBool() {
+		resp.Diagnostics.Append(r.resetRemovedSettings(ctx, prior, data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data.fromSettings(settings)
+	resp.Diagnostics.Append(setAppliedState(ctx, &resp.State, data, req.State.Raw)...)
+}
+
+func (r *ConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
+	data := r.section.newModel()
+
+	resp.Diagnostics.Append(req.State.Get(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// There is no API to delete settings, so they are left in place unless
+	// the defaults are restored
+	if data.toSettings().ResetOnDestroy.ValueBool() {
+		resp.Diagnostics.Append(r.resetRemovedSettings(ctx, data, nil)...)
+	}
+}
+
+// resetRemovedSettings restores the defaults of the settings that are set in
+// prior but not in plan, or of every setting in prior when plan is nil as on
+// destroy. Both are converted to the supabase_settings state, which the reset
+// of supabase_settings works on.
+func (r *ConfigResource) resetRemovedSettings(ctx context.Context, prior configSectionModel, plan configSectionModel) diag.Diagnostics {
+	state := tfsdk.State{Schema: SettingsSchema()}
+	state.Raw = tftypes.NewValue(state.Schema.Type().TerraformType(ctx), nil)
+
+	encode := func(model configSectionModel) (tftypes.Value, diag.Diagnostics) {
+		if model == nil {
+			return state.Raw, nil
+		}
+		settings := model.toSettings()
+		settings.Effective = nullEffective(state)
+		return encodeState(ctx, state, settings)
+	}
+
+	priorRaw, diags := encode(prior)
+	planRaw, d := encode(plan)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(resetRemovedSettings(ctx, r.client, state, priorRaw, planRaw)...)
+	return diags
+}
+
+func (r *ConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !ProjectRefPattern.MatchString(req.ID) {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected a project reference as import ID, got: %s", req.ID),
+		)
+		return
+	}
+
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.AddError(
+			"Unsupported in Local Mode",
+			"Settings cannot be imported when the provider is configured with mode = \"local\", since the local stack reads them from supabase/config.toml.",
+		)
+		return
+	}
+
+	data := r.section.newModel()
+	settings := data.toSettings()
+	settings.ProjectRef = types.StringValue(req.ID)
+	settings.Id = types.StringValue(req.ID)
+	settings.Effective = nullEffective(resp.State)
+	settings.ResetOnDestroy = types.BoolValue(false)
+
+	// Nothing is managed until the configuration sets it, so imported
+	// settings only show up in the effective attribute
+	managed := r.section.newModel()
+	managed.fromSettings(settings)
+	managedRaw, diags := encodeState(ctx, resp.State, managed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.section.read(ctx, r.client, settings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.fromSettings(settings)
+	resp.Diagnostics.Append(setRefreshedState(ctx, &resp.State, data, managedRaw)...)
+}
+
+// MoveState moves the section of the resource from the state of a
+// supabase_settings resource.
+func (r *ConfigResource) MoveState(ctx context.Context) []resource.StateMover {
+	sourceSchema := SettingsSchema()
+
+	return []resource.StateMover{
+		{
+			SourceSchema: &sourceSchema,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				var source SettingsResourceModel
+				if !IsSettingsMoveSource(ctx, req, &source, &resp.Diagnostics) {
+					return
+				}
+
+				data := r.section.newModel()
+				source.Effective = nullEffective(resp.TargetState)
+				if source.ResetOnDestroy.IsNull() {
+					source.ResetOnDestroy = types.BoolValue(false)
+				}
+				data.fromSettings(&source)
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, data)...)
+			},
+		},
+	}
+}
+
+// IsSettingsMoveSource reports whether a move request comes from a
+// supabase_settings resource of this provider, and reads its state into
+// source when it does.
+func IsSettingsMoveSource(ctx context.Context, req resource.MoveStateRequest, source *SettingsResourceModel, diags *diag.Diagnostics) bool {
+	if req.SourceTypeName != "supabase_settings" || !strings.HasSuffix(req.SourceProviderAddress, "/supabase") || req.SourceState == nil {
+		return false
+	}
+
+	diags.Append(req.SourceState.Get(ctx, source)...)
+	if diags.HasError() {
+		return false
+	}
+
+	if source.Id.IsNull() || source.ProjectRef.IsNull() {
+		diags.AddAttributeError(path.Root("id"), "Invalid Source State", "The supabase_settings state has no project identifier.")
+		return false
+	}
+	return true
+}
@@ -3,10 +3,13 @@ package settings
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/supabase/cli/pkg/api"
 )
@@ -43,14 +46,23 @@ func GetDatabaseSchemaAttributes() map[string]schema.Attribute {
 		"effective_cache_size": schema.StringAttribute{
 			MarkdownDescription: "Amount of memory available for disk caching by the OS and within the database itself",
 			Optional:            true,
+			Validators: []validator.String{
+				PostgresMemoryBetween("8kB", 1, math.MaxInt32),
+			},
 		},
 		"logical_decoding_work_mem": schema.StringAttribute{
 			MarkdownDescription: "Memory used for logical decoding",
 			Optional:            true,
+			Validators: []validator.String{
+				PostgresMemoryBetween("kB", 64, math.MaxInt32),
+			},
 		},
 		"maintenance_work_mem": schema.StringAttribute{
 			MarkdownDescription: "Maximum amount of memory to be used by maintenance operations",
 			Optional:            true,
+			Validators: []validator.String{
+				PostgresMemoryBetween("kB", 1024, math.MaxInt32),
+			},
 		},
 		"max_connections": schema.Int64Attribute{
 			MarkdownDescription: "Maximum number of concurrent connections to the database server",
@@ -79,14 +91,23 @@ func GetDatabaseSchemaAttributes() map[string]schema.Attribute {
 		"max_slot_wal_keep_size": schema.StringAttribute{
 			MarkdownDescription: "Maximum size of WAL files that replication slots are allowed to retain",
 			Optional:            true,
+			Validators: []validator.String{
+				PostgresMemoryBetween("MB", -1, math.MaxInt32),
+			},
 		},
 		"max_standby_archive_delay": schema.StringAttribute{
 			MarkdownDescription: "Maximum delay before canceling queries when a hot standby server is processing archived WAL data",
 			Optional:            true,
+			Validators: []validator.String{
+				PostgresDurationBetween("ms", -1, math.MaxInt32),
+			},
 		},
 		"max_standby_streaming_delay": schema.StringAttribute{
 			MarkdownDescription: "Maximum delay before canceling queries when a hot standby server is processing streamed WAL data",
 			Optional:            true,
+			Validators: []validator.String{
+				PostgresDurationBetween("ms", -1, math.MaxInt32),
+			},
 		},
 		"max_wal_senders": schema.Int64Attribute{
 			MarkdownDescription: "Maximum number of WAL sender processes",
@@ -95,6 +116,9 @@ func GetDatabaseSchemaAttributes() map[string]schema.Attribute {
 		"max_wal_size": schema.StringAttribute{
 			MarkdownDescription: "Maximum size to let the WAL grow during automatic checkpoints",
 			Optional:            true,
+			Validators: []validator.String{
+				PostgresMemoryBetween("MB", 2, math.MaxInt32),
+			},
 		},
 		"max_worker_processes": schema.Int64Attribute{
 			MarkdownDescription: "Maximum number of background worker processes",
@@ -107,14 +131,23 @@ func GetDatabaseSchemaAttributes() map[string]schema.Attribute {
 		"session_replication_role": schema.StringAttribute{
 			MarkdownDescription: "Controls firing of replication-related triggers and rules (origin, replica, local)",
 			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("origin", "replica", "local"),
+			},
 		},
 		"shared_buffers": schema.StringAttribute{
 			MarkdownDescription: "Amount of memory the database server uses for shared memory buffers",
 			Optional:            true,
+			Validators: []validator.String{
+				PostgresMemoryBetween("8kB", 16, math.MaxInt32/2),
+			},
 		},
 		"statement_timeout": schema.StringAttribute{
 			MarkdownDescription: "Maximum allowed duration of any statement",
 			Optional:            true,
+			Validators: []validator.String{
+				PostgresDurationBetween("ms", 0, math.MaxInt32),
+			},
 		},
 		"track_commit_timestamp": schema.BoolAttribute{
 			MarkdownDescription: "Whether to track commit time stamps of transactions",
@@ -123,14 +156,23 @@ func GetDatabaseSchemaAttributes() map[string]schema.Attribute {
 		"wal_keep_size": schema.StringAttribute{
 			MarkdownDescription: "Minimum size to retain in the pg_wal directory",
 			Optional:            true,
+			Validators: []validator.String{
+				PostgresMemoryBetween("MB", 0, math.MaxInt32),
+			},
 		},
 		"wal_sender_timeout": schema.StringAttribute{
 			MarkdownDescription: "Maximum time to wait for WAL replication",
 			Optional:            true,
+			Validators: []validator.String{
+				PostgresDurationBetween("ms", 0, math.MaxInt32),
+			},
 		},
 		"work_mem": schema.StringAttribute{
 			MarkdownDescription: "Amount of memory to be used by internal sort operations and hash tables",
 			Optional:            true,
+			Validators: []validator.String{
+				PostgresMemoryBetween("kB", 64, math.MaxInt32),
+			},
 		},
 	}
 }
@@ -199,12 +241,30 @@ func UpdateDatabaseConfig(ctx context.Context, client *api.ClientWithResponses,
 		val := int(plan.Database.MaxConnections.ValueInt64())
 		body.MaxConnections = &val
 	}
+	body.MaxLocksPerTransaction = intPointer(plan.Database.MaxLocksPerTransaction)
+	body.MaxParallelMaintenanceWorkers = intPointer(plan.Database.MaxParallelMaintenanceWorkers)
+	body.MaxParallelWorkers = intPointer(plan.Database.MaxParallelWorkers)
+	body.MaxParallelWorkersPerGather = intPointer(plan.Database.MaxParallelWorkersPerGather)
+	body.MaxReplicationSlots = intPointer(plan.Database.MaxReplicationSlots)
+	body.MaxSlotWalKeepSize = plan.Database.MaxSlotWalKeepSize.ValueStringPointer()
+	body.MaxStandbyArchiveDelay = plan.Database.MaxStandbyArchiveDelay.ValueStringPointer()
+	body.MaxStandbyStreamingDelay = plan.Database.MaxStandbyStreamingDelay.ValueStringPointer()
+	body.MaxWalSenders = intPointer(plan.Database.MaxWalSenders)
+	body.MaxWalSize = plan.Database.MaxWalSize.ValueStringPointer()
+	body.MaxWorkerProcesses = intPointer(plan.Database.MaxWorkerProcesses)
+	if !plan.Database.SessionReplicationRole.IsNull() {
+		val := api.UpdatePostgresConfigBodySessionReplicationRole(plan.Database.SessionReplicationRole.ValueString())
+		body.SessionReplicationRole = &val
+	}
 	if !plan.Database.StatementTimeout.IsNull() {
 		body.StatementTimeout = plan.Database.StatementTimeout.ValueStringPointer()
 	}
 	if !plan.Database.SharedBuffers.IsNull() {
 		body.SharedBuffers = plan.Database.SharedBuffers.ValueStringPointer()
 	}
+	body.TrackCommitTimestamp = plan.Database.TrackCommitTimestamp.ValueBoolPointer()
+	body.WalKeepSize = plan.Database.WalKeepSize.ValueStringPointer()
+	body.WalSenderTimeout = plan.Database.WalSenderTimeout.ValueStringPointer()
 	if !plan.Database.WorkMem.IsNull() {
 		body.WorkMem = plan.Database.WorkMem.ValueStringPointer()
 	}
@@ -223,3 +283,13 @@ func UpdateDatabaseConfig(ctx context.Context, client *api.ClientWithResponses,
 
 	return nil
 }
+
+// intPointer returns the value of an optional number attribute as sent to the
+// API, nil when it is null.
+func intPointer(value types.Int64) *int {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	val := int(value.ValueInt64())
+	return &val
+}
@@ -0,0 +1,86 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PlanProjectRef fills in project_ref from the provider configuration when a
+// resource omits it. The attribute forces replacement, so a change of the
+// provider default replaces the resource.
+func (d *SupabaseProviderData) PlanProjectRef(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if d == nil {
+		return
+	}
+	planProviderDefault(ctx, req, resp, "project_ref", d.ProjectRef, true)
+}
+
+// PlanOrganizationId fills in organization_id from the provider configuration
+// when a resource omits it.
+func (d *SupabaseProviderData) PlanOrganizationId(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if d == nil {
+		return
+	}
+	planProviderDefault(ctx, req, resp, "organization_id", d.OrganizationId, false)
+}
+
+// ResolveProjectRef falls back to the provider project_ref when a data source
+// omits it.
+func (d *SupabaseProviderData) ResolveProjectRef(projectRef *types.String) diag.Diagnostics {
+	if !projectRef.IsNull() && !projectRef.IsUnknown() {
+		return nil
+	}
+
+	if d == nil || d.ProjectRef == "" {
+		return diag.Diagnostics{diag.WithPath(path.Root("project_ref"), missingDefaultDiagnostic("project_ref"))}
+	}
+	*projectRef = types.StringValue(d.ProjectRef)
+	return nil
+}
+
+// planProviderDefault sets a top level string attribute omitted from the
+// configuration to the provider level default of the same name.
+func planProviderDefault(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, attribute string, value string, replace bool) {
+	// Nothing to plan when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	attributePath := path.Root(attribute)
+
+	var configured types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, attributePath, &configured)...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() {
+		return
+	}
+
+	if value == "" {
+		missing := missingDefaultDiagnostic(attribute)
+		resp.Diagnostics.AddAttributeError(attributePath, missing.Summary(), missing.Detail())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, attributePath, types.StringValue(value))...)
+
+	if !replace || req.State.Raw.IsNull() {
+		return
+	}
+
+	var current types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, attributePath, &current)...)
+	if !current.IsNull() && current.ValueString() != value {
+		resp.RequiresReplace = append(resp.RequiresReplace, attributePath)
+	}
+}
+
+func missingDefaultDiagnostic(attribute string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		fmt.Sprintf("Missing %s", attribute),
+		fmt.Sprintf("%s must be set either on the resource or on the provider.", attribute),
+	)
+}
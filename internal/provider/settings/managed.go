@@ -0,0 +1,232 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// effectiveAttributeName is the computed attribute exposing every setting as
+// read from the API, including those the configuration does not manage.
+const effectiveAttributeName = "effective"
+
+// alwaysRefreshed are the top level attributes that are refreshed whether or
+// not they were set before.
+var alwaysRefreshed = map[string]bool{
+	"project_ref":          true,
+	"id":                   true,
+	"reset_on_destroy":     true,
+	effectiveAttributeName: true,
+}
+
+// effectiveAttribute returns the effective attribute for a resource with the
+// given settings attributes. Sensitive attributes are left out, since the API
+// never returns them, and so are the versions of write-only secrets.
+func effectiveAttribute(attributes map[string]schema.Attribute) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "All settings as read from the API, including those that are not set in the configuration and therefore not managed by this resource",
+		Computed:            true,
+		Attributes:          computedAttributes(attributes),
+	}
+}
+
+func computedAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	computed := make(map[string]schema.Attribute, len(attributes))
+
+	for name, attribute := range attributes {
+		if attribute.IsSensitive() || strings.HasSuffix(name, writeOnlyVersionSuffix) {
+			continue
+		}
+
+		description := attribute.GetMarkdownDescription()
+		switch attribute := attribute.(type) {
+		case schema.StringAttribute:
+			computed[name] = schema.StringAttribute{MarkdownDescription: description, Computed: true}
+		case schema.Int64Attribute:
+			computed[name] = schema.Int64Attribute{MarkdownDescription: description, Computed: true}
+		case schema.BoolAttribute:
+			computed[name] = schema.BoolAttribute{MarkdownDescription: description, Computed: true}
+		case schema.ListAttribute:
+			computed[name] = schema.ListAttribute{MarkdownDescription: description, Computed: true, ElementType: attribute.ElementType}
+		case schema.SingleNestedAttribute:
+			if nested := computedAttributes(attribute.Attributes); len(nested) > 0 {
+				computed[name] = schema.SingleNestedAttribute{MarkdownDescription: description, Computed: true, Attributes: nested}
+			}
+		}
+	}
+
+	return computed
+}
+
+// setRefreshedState sets state to a model refreshed from the API. Attributes
+// that are null in managed, usually the prior state, stay null, so that
+// server values the configuration does not set never show up as a diff.
+// Every refreshed value is exposed in the effective attribute instead.
+func setRefreshedState(ctx context.Context, state *tfsdk.State, refreshed any, managed tftypes.Value) diag.Diagnostics {
+	raw, diags := encodeState(ctx, *state, refreshed)
+	if diags.HasError() {
+		return diags
+	}
+
+	masked, err := managedOnly(raw, managed)
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to filter unmanaged settings: %s", err))}
+	}
+
+	return setWithEffective(ctx, state, masked, func(effectiveType tftypes.Object) (tftypes.Value, error) {
+		return effectiveValue(raw, effectiveType)
+	})
+}
+
+// setAppliedState sets state to an applied model. The effective attribute
+// keeps the values last read from the API, overridden by the applied ones,
+// until the next refresh reads them all again.
+func setAppliedState(ctx context.Context, state *tfsdk.State, applied any, prior tftypes.Value) diag.Diagnostics {
+	raw, diags := encodeState(ctx, *state, applied)
+	if diags.HasError() {
+		return diags
+	}
+
+	return setWithEffective(ctx, state, raw, func(effectiveType tftypes.Object) (tftypes.Value, error) {
+		effective, err := effectiveValue(raw, effectiveType)
+		if err != nil || prior.IsNull() || !prior.IsKnown() {
+			return effective, err
+		}
+
+		var priorAttributes map[string]tftypes.Value
+		if err := prior.As(&priorAttributes); err != nil {
+			return effective, err
+		}
+		return withPriorValues(effective, priorAttributes[effectiveAttributeName])
+	})
+}
+
+func setWithEffective(ctx context.Context, state *tfsdk.State, raw tftypes.Value, effective func(effectiveType tftypes.Object) (tftypes.Value, error)) diag.Diagnostics {
+	objectType, ok := state.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", "Unable to set effective settings: the resource schema is not an object")}
+	}
+	effectiveType, ok := objectType.AttributeTypes[effectiveAttributeName].(tftypes.Object)
+	if !ok {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", "Unable to set effective settings: the resource has no effective attribute")}
+	}
+
+	value, err := effective(effectiveType)
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to set effective settings: %s", err))}
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := raw.As(&attributes); err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to set effective settings: %s", err))}
+	}
+	attributes[effectiveAttributeName] = value
+
+	state.Raw = tftypes.NewValue(objectType, attributes)
+	return nil
+}
+
+// nullEffective returns a null effective attribute in the schema of state,
+// for models that are not read from a plan or state.
+func nullEffective(state tfsdk.State) types.Object {
+	objectType, _ := state.Schema.GetAttributes()[effectiveAttributeName].GetType().(types.ObjectType)
+	return types.ObjectNull(objectType.AttrTypes)
+}
+
+// encodeState returns the Terraform value of a model in the schema of state.
+func encodeState(ctx context.Context, state tfsdk.State, model any) (tftypes.Value, diag.Diagnostics) {
+	encoded := tfsdk.State{
+		Schema: state.Schema,
+		Raw:    tftypes.NewValue(state.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := encoded.Set(ctx, model)
+	return encoded.Raw, diags
+}
+
+// managedOnly returns refreshed with every attribute that is null in managed
+// set to null. Elements of lists are kept as a whole.
+func managedOnly(refreshed tftypes.Value, managed tftypes.Value) (tftypes.Value, error) {
+	return tftypes.Transform(refreshed, func(path *tftypes.AttributePath, value tftypes.Value) (tftypes.Value, error) {
+		steps := path.Steps()
+		if len(steps) == 0 {
+			return value, nil
+		}
+		for _, step := range steps {
+			if _, ok := step.(tftypes.AttributeName); !ok {
+				return value, nil
+			}
+		}
+		if alwaysRefreshed[string(steps[0].(tftypes.AttributeName))] {
+			return value, nil
+		}
+
+		current, _, err := tftypes.WalkAttributePath(managed, path)
+		if err == nil {
+			if current, ok := current.(tftypes.Value); ok && !current.IsNull() {
+				return value, nil
+			}
+		}
+		return tftypes.NewValue(value.Type(), nil), nil
+	})
+}
+
+// effectiveValue copies the attributes of the effective type from refreshed.
+func effectiveValue(refreshed tftypes.Value, effectiveType tftypes.Object) (tftypes.Value, error) {
+	if refreshed.IsNull() || !refreshed.IsKnown() {
+		return tftypes.NewValue(effectiveType, nil), nil
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := refreshed.As(&attributes); err != nil {
+		return tftypes.Value{}, err
+	}
+
+	values := make(map[string]tftypes.Value, len(effectiveType.AttributeTypes))
+	for name, attributeType := range effectiveType.AttributeTypes {
+		value, ok := attributes[name]
+		if !ok {
+			values[name] = tftypes.NewValue(attributeType, nil)
+			continue
+		}
+
+		if nestedType, ok := attributeType.(tftypes.Object); ok {
+			nested, err := effectiveValue(value, nestedType)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			value = nested
+		}
+		values[name] = value
+	}
+
+	return tftypes.NewValue(effectiveType, values), nil
+}
+
+// withPriorValues returns value with its null attributes set to the value of
+// the same attribute in prior, when that is known.
+func withPriorValues(value tftypes.Value, prior tftypes.Value) (tftypes.Value, error) {
+	if prior.IsNull() || !prior.IsKnown() {
+		return value, nil
+	}
+
+	return tftypes.Transform(value, func(path *tftypes.AttributePath, current tftypes.Value) (tftypes.Value, error) {
+		if !current.IsNull() {
+			return current, nil
+		}
+
+		previous, _, err := tftypes.WalkAttributePath(prior, path)
+		if err != nil {
+			return current, nil
+		}
+		if previous, ok := previous.(tftypes.Value); ok && previous.IsKnown() && previous.Type().Equal(current.Type()) {
+			return previous, nil
+		}
+		return current, nil
+	})
+}
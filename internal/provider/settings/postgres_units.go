@@ -0,0 +1,150 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// postgresMemoryUnits are the memory units Postgres accepts, in kilobytes.
+var postgresMemoryUnits = map[string]float64{
+	"B":  1.0 / 1024,
+	"kB": 1,
+	"MB": 1024,
+	"GB": 1024 * 1024,
+	"TB": 1024 * 1024 * 1024,
+}
+
+// postgresDurationUnits are the time units Postgres accepts, in milliseconds.
+var postgresDurationUnits = map[string]float64{
+	"us":  0.001,
+	"ms":  1,
+	"s":   1000,
+	"min": 60 * 1000,
+	"h":   60 * 60 * 1000,
+	"d":   24 * 60 * 60 * 1000,
+}
+
+// ParsePostgresMemory parses a memory setting such as 128MB and returns it in
+// kilobytes. A value without a unit is in baseUnit, the unit of the
+// parameter, such as kB for work_mem or 8kB for shared_buffers.
+func ParsePostgresMemory(value string, baseUnit string) (float64, error) {
+	return parsePostgresQuantity(value, baseUnit, postgresMemoryUnits)
+}
+
+// ParsePostgresDuration parses a time setting such as 30s and returns it in
+// milliseconds. A value without a unit is in baseUnit, the unit of the
+// parameter, such as ms for statement_timeout.
+func ParsePostgresDuration(value string, baseUnit string) (float64, error) {
+	return parsePostgresQuantity(value, baseUnit, postgresDurationUnits)
+}
+
+// parsePostgresQuantity follows the parsing of integer settings in Postgres:
+// a number, optional whitespace, and an optional case sensitive unit.
+func parsePostgresQuantity(value string, baseUnit string, units map[string]float64) (float64, error) {
+	trimmed := strings.TrimSpace(value)
+	end := strings.IndexFunc(trimmed, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '-' && r != '+'
+	})
+	if end == -1 {
+		end = len(trimmed)
+	}
+
+	number, err := strconv.ParseFloat(trimmed[:end], 64)
+	if err != nil || math.IsInf(number, 0) || math.IsNaN(number) {
+		return 0, fmt.Errorf("%q is not a number with an optional unit", value)
+	}
+
+	unit := strings.TrimSpace(trimmed[end:])
+	if unit == "" {
+		unit = baseUnit
+	}
+
+	factor, err := postgresUnitFactor(unit, units)
+	if err != nil {
+		return 0, fmt.Errorf("%q has an invalid unit: %w", value, err)
+	}
+	return number * factor, nil
+}
+
+// postgresUnitFactor returns the size of a unit, including multiples of a
+// unit such as the 8kB pages of shared_buffers.
+func postgresUnitFactor(unit string, units map[string]float64) (float64, error) {
+	if factor, ok := units[unit]; ok {
+		return factor, nil
+	}
+
+	end := strings.IndexFunc(unit, func(r rune) bool { return r < '0' || r > '9' })
+	if end > 0 {
+		if factor, ok := units[unit[end:]]; ok {
+			multiple, err := strconv.Atoi(unit[:end])
+			if err == nil {
+				return float64(multiple) * factor, nil
+			}
+		}
+	}
+
+	names := make([]string, 0, len(units))
+	for name := range units {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return units[names[i]] < units[names[j]] })
+	return 0, fmt.Errorf("valid units are %s", strings.Join(names, ", "))
+}
+
+// PostgresMemoryBetween validates a memory setting in baseUnit, with bounds
+// given in baseUnit as in the Postgres documentation.
+func PostgresMemoryBetween(baseUnit string, min int64, max int64) validator.String {
+	return postgresQuantityValidator{kind: "memory", baseUnit: baseUnit, min: min, max: max, parse: ParsePostgresMemory}
+}
+
+// PostgresDurationBetween validates a time setting in baseUnit, with bounds
+// given in baseUnit as in the Postgres documentation.
+func PostgresDurationBetween(baseUnit string, min int64, max int64) validator.String {
+	return postgresQuantityValidator{kind: "duration", baseUnit: baseUnit, min: min, max: max, parse: ParsePostgresDuration}
+}
+
+type postgresQuantityValidator struct {
+	kind     string
+	baseUnit string
+	min      int64
+	max      int64
+	parse    func(value string, baseUnit string) (float64, error)
+}
+
+func (v postgresQuantityValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be a Postgres %s between %d%s and %d%s", v.kind, v.min, v.baseUnit, v.max, v.baseUnit)
+}
+
+func (v postgresQuantityValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v postgresQuantityValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	parsed, err := v.parse(value, v.baseUnit)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, fmt.Sprintf("Invalid Postgres %s", v.kind), err.Error())
+		return
+	}
+
+	// Postgres rounds to the unit of the parameter before checking bounds
+	base, _ := v.parse("1", v.baseUnit)
+	rounded := math.Round(parsed / base)
+	if rounded < float64(v.min) || rounded > float64(v.max) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			fmt.Sprintf("Invalid Postgres %s", v.kind),
+			fmt.Sprintf("%q is out of range, it must be between %d%s and %d%s.", value, v.min, v.baseUnit, v.max, v.baseUnit),
+		)
+	}
+}
@@ -0,0 +1,422 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/supabase/cli/pkg/api"
+)
+
+// SettingsDefaultsVersion is the revision of settingsDefaults. Change it
+// along with any default, so that the defaults a reset restored can be traced
+// back from the logs.
+const SettingsDefaultsVersion = "2026-10-18"
+
+// settingsDefaults are the documented defaults of new Supabase projects, by
+// section and attribute. A reset sends them through the Update*Config
+// functions. A null default is sent as null, which makes the pooler fall back
+// to the default of the compute size.
+var settingsDefaults = map[string]map[string]attr.Value{
+	"database": {
+		"logical_decoding_work_mem":   types.StringValue("64MB"),
+		"max_locks_per_transaction":   types.Int64Value(64),
+		"max_replication_slots":       types.Int64Value(10),
+		"max_standby_archive_delay":   types.StringValue("30s"),
+		"max_standby_streaming_delay": types.StringValue("30s"),
+		"max_wal_senders":             types.Int64Value(10),
+		"session_replication_role":    types.StringValue("origin"),
+		"statement_timeout":           types.StringValue("2min"),
+		"track_commit_timestamp":      types.BoolValue(false),
+		"wal_keep_size":               types.StringValue("0MB"),
+		"wal_sender_timeout":          types.StringValue("1min"),
+	},
+	"network": {
+		"db_allowed_cidrs":    types.ListValueMust(types.StringType, []attr.Value{types.StringValue("0.0.0.0/0")}),
+		"db_allowed_cidrs_v6": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("::/0")}),
+	},
+	"api": {
+		"db_schema":            types.StringValue("public,graphql_public"),
+		"db_extra_search_path": types.StringValue("public,extensions"),
+		"max_rows":             types.Int64Value(1000),
+	},
+	"auth": authSettingsDefaults(),
+	"storage": {
+		"file_size_limit": types.Int64Value(52428800),
+	},
+	"pooler": {
+		"default_pool_size": types.Int64Null(),
+	},
+}
+
+// settingsWithoutDefaults are the attributes that a reset leaves in place, by
+// section: credentials and values specific to the project, settings whose
+// default depends on the compute size or plan, and settings that the API
+// cannot clear.
+var settingsWithoutDefaults = map[string][]string{
+	"database": {
+		// Depend on the compute size
+		"effective_cache_size",
+		"maintenance_work_mem",
+		"max_connections",
+		"max_parallel_maintenance_workers",
+		"max_parallel_workers",
+		"max_parallel_workers_per_gather",
+		"max_slot_wal_keep_size",
+		"max_wal_size",
+		"max_worker_processes",
+		"shared_buffers",
+		"work_mem",
+		// Not a setting
+		"restart_database",
+	},
+	"api": {
+		// Depends on the compute size and cannot be sent as null
+		"db_pool",
+	},
+	"auth": {
+		// Depends on the compute size
+		"db_max_pool_size",
+		// Credentials and values specific to the project
+		"external_apple_client_id",
+		"external_azure_client_id",
+		"external_discord_client_id",
+		"external_facebook_client_id",
+		"external_github_client_id",
+		"external_google_client_id",
+		"hook_custom_access_token_secrets",
+		"hook_custom_access_token_uri",
+		"hook_mfa_verification_attempt_secrets",
+		"hook_mfa_verification_attempt_uri",
+		"hook_password_verification_attempt_secrets",
+		"hook_password_verification_attempt_uri",
+		"hook_send_email_secrets",
+		"hook_send_email_uri",
+		"hook_send_sms_secrets",
+		"hook_send_sms_uri",
+		"saml_external_url",
+		"security_captcha_secret",
+		"smtp_admin_email",
+		"smtp_host",
+		"smtp_pass",
+		"smtp_port",
+		"smtp_sender_name",
+		"smtp_user",
+		"sms_messagebird_access_key",
+		"sms_messagebird_originator",
+		"sms_textlocal_api_key",
+		"sms_textlocal_sender",
+		"sms_twilio_account_sid",
+		"sms_twilio_auth_token",
+		"sms_twilio_content_sid",
+		"sms_twilio_message_service_sid",
+		"sms_twilio_verify_account_sid",
+		"sms_twilio_verify_auth_token",
+		"sms_twilio_verify_message_service_sid",
+		"sms_vonage_api_key",
+		"sms_vonage_api_secret",
+		"sms_vonage_from",
+		// Cannot be cleared through the API
+		"saml_allow_encrypted_assertions",
+		"sms_test_otp_valid_until",
+	},
+	"storage": {
+		// Depend on the plan
+		"features",
+	},
+}
+
+// authSettingsDefaults returns the defaults of the auth section. Every
+// external provider is disabled, including the ones only configured through
+// their nested attribute.
+func authSettingsDefaults() map[string]attr.Value {
+	defaults := map[string]attr.Value{
+		"api_max_request_duration":     types.Int64Value(10),
+		"disable_signup":               types.BoolValue(false),
+		"jwt_exp":                      types.Int64Value(3600),
+		"password_hibp_enabled":        types.BoolValue(false),
+		"password_min_length":          types.Int64Value(6),
+		"password_required_characters": types.StringValue(""),
+		"site_url":                     types.StringValue("http://localhost:3000"),
+		"uri_allow_list":               types.StringValue(""),
+
+		"external_anonymous_users_enabled": types.BoolValue(false),
+		"external_email_enabled":           types.BoolValue(true),
+		"external_phone_enabled":           types.BoolValue(false),
+		"external_google_skip_nonce_check": types.BoolValue(false),
+
+		"rate_limit_anonymous_users":                        types.Int64Value(30),
+		"rate_limit_email_sent":                             types.Int64Value(2),
+		"rate_limit_otp":                                    types.Int64Value(30),
+		"rate_limit_sms_sent":                               types.Int64Value(30),
+		"rate_limit_token_refresh":                          types.Int64Value(150),
+		"rate_limit_verify":                                 types.Int64Value(30),
+		"refresh_token_rotation_enabled":                    types.BoolValue(true),
+		"saml_enabled":                                      types.BoolValue(false),
+		"security_captcha_enabled":                          types.BoolValue(false),
+		"security_captcha_provider":                         types.StringValue("hcaptcha"),
+		"security_manual_linking_enabled":                   types.BoolValue(false),
+		"security_refresh_token_reuse_interval":             types.Int64Value(10),
+		"security_update_password_require_reauthentication": types.BoolValue(false),
+		"sessions_inactivity_timeout":                       types.Int64Value(0),
+		"sessions_single_per_user":                          types.BoolValue(false),
+		"sessions_tags":                                     types.StringValue(""),
+		"sessions_timebox":                                  types.Int64Value(0),
+
+		"mailer_allow_unverified_email_sign_ins":    types.BoolValue(false),
+		"mailer_autoconfirm":                        types.BoolValue(false),
+		"mailer_otp_exp":                            types.Int64Value(3600),
+		"mailer_otp_length":                         types.Int64Value(6),
+		"mailer_secure_email_change_enabled":        types.BoolValue(true),
+		"mailer_subjects_confirmation":              types.StringValue("Confirm Your Signup"),
+		"mailer_subjects_email_change":              types.StringValue("Confirm Email Change"),
+		"mailer_subjects_invite":                    types.StringValue("You have been invited"),
+		"mailer_subjects_magic_link":                types.StringValue("Your Magic Link"),
+		"mailer_subjects_reauthentication":          types.StringValue("Confirm reauthentication"),
+		"mailer_subjects_recovery":                  types.StringValue("Reset Your Password"),
+		"mailer_templates_confirmation_content":     types.StringValue("<h2>Confirm your signup</h2>\n\n<p>Follow this link to confirm your user:</p>\n<p><a href=\"{{ .ConfirmationURL }}\">Confirm your mail</a></p>"),
+		"mailer_templates_email_change_content":     types.StringValue("<h2>Confirm Change of Email</h2>\n\n<p>Follow this link to confirm the update of your email from {{ .Email }} to {{ .NewEmail }}:</p>\n<p><a href=\"{{ .ConfirmationURL }}\">Change Email</a></p>"),
+		"mailer_templates_invite_content":           types.StringValue("<h2>You have been invited</h2>\n\n<p>You have been invited to create a user on {{ .SiteURL }}. Follow this link to accept the invite:</p>\n<p><a href=\"{{ .ConfirmationURL }}\">Accept the invite</a></p>"),
+		"mailer_templates_magic_link_content":       types.StringValue("<h2>Magic Link</h2>\n\n<p>Follow this link to login:</p>\n<p><a href=\"{{ .ConfirmationURL }}\">Log In</a></p>"),
+		"mailer_templates_reauthentication_content": types.StringValue("<h2>Confirm reauthentication</h2>\n\n<p>Enter the code: {{ .Token }}</p>"),
+		"mailer_templates_recovery_content":         types.StringValue("<h2>Reset Password</h2>\n\n<p>Follow this link to reset the password for your user:</p>\n<p><a href=\"{{ .ConfirmationURL }}\">Reset Password</a></p>"),
+		"smtp_max_frequency":                        types.Int64Value(60),
+
+		"sms_autoconfirm":   types.BoolValue(false),
+		"sms_max_frequency": types.Int64Value(60),
+		"sms_otp_exp":       types.Int64Value(60),
+		"sms_otp_length":    types.Int64Value(6),
+		"sms_provider":      types.StringValue("twilio"),
+		"sms_template":      types.StringValue("Your code is {{ .Code }}"),
+		"sms_test_otp":      types.StringValue(""),
+
+		"mfa_max_enrolled_factors":     types.Int64Value(10),
+		"mfa_phone_enroll_enabled":     types.BoolValue(false),
+		"mfa_phone_max_frequency":      types.Int64Value(5),
+		"mfa_phone_otp_length":         types.Int64Value(6),
+		"mfa_phone_template":           types.StringValue("Your code is {{ .Code }}"),
+		"mfa_phone_verify_enabled":     types.BoolValue(false),
+		"mfa_totp_enroll_enabled":      types.BoolValue(true),
+		"mfa_totp_verify_enabled":      types.BoolValue(true),
+		"mfa_web_authn_enroll_enabled": types.BoolValue(false),
+		"mfa_web_authn_verify_enabled": types.BoolValue(false),
+
+		"hook_custom_access_token_enabled":           types.BoolValue(false),
+		"hook_mfa_verification_attempt_enabled":      types.BoolValue(false),
+		"hook_password_verification_attempt_enabled": types.BoolValue(false),
+		"hook_send_email_enabled":                    types.BoolValue(false),
+		"hook_send_sms_enabled":                      types.BoolValue(false),
+	}
+
+	disabled := externalProviderDisabled()
+	for name, attribute := range GetAuthExternalSchemaAttributes() {
+		switch {
+		case strings.HasSuffix(name, "_enabled"):
+			if _, ok := defaults[name]; !ok {
+				defaults[name] = types.BoolValue(false)
+			}
+		case attribute.GetType().Equal(disabled.Type(context.Background())):
+			defaults[name] = disabled
+		}
+	}
+	return defaults
+}
+
+// externalProviderDisabled returns the nested configuration of an external
+// provider that only disables it.
+func externalProviderDisabled() types.Object {
+	attributeTypes := map[string]attr.Type{}
+	values := map[string]attr.Value{}
+	for name, attribute := range GetExternalProviderSchemaAttributes("") {
+		attributeTypes[name] = attribute.GetType()
+		values[name] = nullValue(attribute.GetType())
+	}
+	values["enabled"] = types.BoolValue(false)
+	return types.ObjectValueMust(attributeTypes, values)
+}
+
+// nullValue returns the null value of a string, number or bool type.
+func nullValue(t attr.Type) attr.Value {
+	switch t {
+	case types.BoolType:
+		return types.BoolNull()
+	case types.Int64Type:
+		return types.Int64Null()
+	default:
+		return types.StringNull()
+	}
+}
+
+// SettingDefault returns the default that a reset restores for an attribute of
+// a section of supabase_settings, and whether there is one.
+func SettingDefault(section string, name string) (attr.Value, bool) {
+	value, ok := settingsDefaults[section][name]
+	return value, ok
+}
+
+// SettingKeptOnReset reports whether a reset deliberately leaves an attribute
+// of a section of supabase_settings in place.
+func SettingKeptOnReset(section string, name string) bool {
+	return slices.Contains(settingsWithoutDefaults[section], name)
+}
+
+// resetRemovedSettings restores the defaults of the settings that are set in
+// prior but not in plan, or of every setting in prior when plan is null as on
+// destroy. Settings without a default are left in place with a warning.
+func resetRemovedSettings(ctx context.Context, client *api.ClientWithResponses, state tfsdk.State, prior tftypes.Value, plan tftypes.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	removed := removedSettings(prior, plan)
+	if len(removed) == 0 {
+		return diags
+	}
+
+	var projectRef types.String
+	diags.Append(tfsdk.State{Schema: state.Schema, Raw: prior}.GetAttribute(ctx, path.Root("project_ref"), &projectRef)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	var kept []string
+	for _, section := range settingsSections {
+		names := removed[section.name]
+		if len(names) == 0 {
+			continue
+		}
+
+		// Start from an empty section, so that only the defaults are sent
+		data := SettingsResourceModel{
+			ProjectRef: projectRef,
+			Id:         projectRef,
+			Effective:  nullEffective(state),
+		}
+		section.set(&data, true)
+		raw, d := encodeState(ctx, state, &data)
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+		model := tfsdk.State{Schema: state.Schema, Raw: raw}
+		if section.replaced && !plan.IsNull() {
+			var planned types.Object
+			diags.Append(tfsdk.State{Schema: state.Schema, Raw: plan}.GetAttribute(ctx, path.Root(section.name), &planned)...)
+			if !planned.IsNull() {
+				diags.Append(model.SetAttribute(ctx, path.Root(section.name), planned)...)
+			}
+		}
+
+		var reset []string
+		for _, name := range names {
+			value, ok := SettingDefault(section.name, name)
+			if !ok {
+				kept = append(kept, section.name+"."+name)
+				continue
+			}
+			diags.Append(model.SetAttribute(ctx, path.Root(section.name).AtName(name), value)...)
+			reset = append(reset, name)
+		}
+		if len(reset) == 0 {
+			continue
+		}
+
+		diags.Append(model.Get(ctx, &data)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		tflog.Debug(ctx, "resetting settings to their defaults", map[string]any{
+			"section":          section.name,
+			"attributes":       reset,
+			"defaults_version": SettingsDefaultsVersion,
+		})
+		diags.Append(section.update(ctx, client, &data)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if len(kept) > 0 {
+		diags.AddWarning(
+			"Settings Left in Place",
+			fmt.Sprintf("There is no known default for %s, so the current values were kept.", strings.Join(kept, ", ")),
+		)
+	}
+
+	return diags
+}
+
+// removedSettings returns the names of the attributes set in prior but not in
+// plan, by section.
+func removedSettings(prior tftypes.Value, plan tftypes.Value) map[string][]string {
+	removed := map[string][]string{}
+
+	for _, section := range settingsSections {
+		sectionPath := tftypes.NewAttributePath().WithAttributeName(section.name)
+		priorAttributes := objectAttributes(prior, sectionPath)
+		planAttributes := objectAttributes(plan, sectionPath)
+
+		for _, name := range sortedKeys(priorAttributes) {
+			if priorAttributes[name].IsNull() || strings.HasSuffix(name, writeOnlyVersionSuffix) {
+				continue
+			}
+			if planned, ok := planAttributes[name]; ok && !planned.IsNull() {
+				continue
+			}
+			removed[section.name] = append(removed[section.name], name)
+		}
+
+		// The update of a replaced section would clear its other null
+		// attributes, so they are reset as well
+		if section.replaced && len(removed[section.name]) > 0 {
+			removed[section.name] = nil
+			for _, name := range sortedKeys(priorAttributes) {
+				if planned, ok := planAttributes[name]; !ok || planned.IsNull() {
+					removed[section.name] = append(removed[section.name], name)
+				}
+			}
+		}
+	}
+
+	return removed
+}
+
+// objectAttributes returns the attributes of the object at attributePath in
+// value, or none when the object is null or missing.
+func objectAttributes(value tftypes.Value, attributePath *tftypes.AttributePath) map[string]tftypes.Value {
+	if value.IsNull() || !value.IsKnown() {
+		return nil
+	}
+
+	current, _, err := tftypes.WalkAttributePath(value, attributePath)
+	if err != nil {
+		return nil
+	}
+	object, ok := current.(tftypes.Value)
+	if !ok || object.IsNull() || !object.IsKnown() {
+		return nil
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := object.As(&attributes); err != nil {
+		return nil
+	}
+	return attributes
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
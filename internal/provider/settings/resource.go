@@ -3,33 +3,106 @@ package settings
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/supabase/cli/pkg/api"
 )
 
+// Provider modes
+const (
+	ModeRemote = "remote" // Supabase platform through the Management API
+	ModeLocal  = "local"  // Local stack started with `supabase start`
+)
+
+// ProjectRefPattern matches the reference of a project on the Supabase platform
+var ProjectRefPattern = regexp.MustCompile(`^[a-z]{20}$`)
+
 // SupabaseProviderData defines provider data structure
 type SupabaseProviderData struct {
-	ManagementClient *api.ClientWithResponses
+	Mode             string
+	LocalAPIURL      string                   // API gateway of the local stack in local mode
+	LocalAnonKey     string                   // Anon key of the local stack in local mode
+	ManagementClient *api.ClientWithResponses // Shares the rate limiter of the provider configuration
 	AccessToken      string
 	TokenManager     TokenManager // Interface for token management
+	StorageEndpoints StorageEndpointResolver
+	ServiceRoleKey   string       // Overrides the service role key fetched by the TokenManager
+	HTTPClient       *http.Client // Retrying client shared by storage and local API calls
+	ProjectRef       string       // Default for resources that omit project_ref
+	OrganizationId   string       // Default for resources that omit organization_id
+}
+
+// RequireManagementAPI returns a warning diagnostic when the provider runs in
+// local mode, where resources backed by the Management API are skipped.
+func (d *SupabaseProviderData) RequireManagementAPI(typeName string) diag.Diagnostics {
+	if !d.SkipsManagementAPI() {
+		return nil
+	}
+
+	return diag.Diagnostics{diag.NewWarningDiagnostic(
+		"Skipped in Local Mode",
+		fmt.Sprintf("%s requires the Supabase Management API, which is not available when the provider is configured with mode = \"local\", so it is skipped. "+
+			"Its planned values are kept in state without being applied. "+
+			"The local stack reads its settings from supabase/config.toml and serves the functions in supabase/functions automatically. "+
+			"Storage buckets, objects, policies, and database webhooks are applied in local mode, and supabase_apikeys returns the keys of the local stack.", typeName),
+	)}
+}
+
+// SkipsManagementAPI reports whether resources backed by the Management API
+// are skipped, which they are in local mode.
+func (d *SupabaseProviderData) SkipsManagementAPI() bool {
+	return d != nil && d.Mode == ModeLocal
+}
+
+// SetSkippedState sets the state of a skipped resource to its plan, with the
+// unknown values that the Management API would have computed set to null.
+func SetSkippedState(plan tfsdk.Plan, state *tfsdk.State) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	raw, err := tftypes.Transform(plan.Raw, func(_ *tftypes.AttributePath, value tftypes.Value) (tftypes.Value, error) {
+		if !value.IsKnown() {
+			return tftypes.NewValue(value.Type(), nil), nil
+		}
+		return value, nil
+	})
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to build the state of a skipped resource: %s", err))
+		return diags
+	}
+
+	state.Raw = raw
+	return diags
 }
 
 // TokenManager interface for getting appropriate tokens for different APIs
 type TokenManager interface {
 	GetServiceRoleToken(ctx context.Context, projectRef string) (string, error)
-	GetManagementToken() string
+	GetManagementToken(ctx context.Context) (string, error)
 	InvalidateProjectTokens(projectRef string)
 }
 
+// StorageEndpointResolver interface for resolving the Storage API URL of a project
+type StorageEndpointResolver interface {
+	StorageURL(ctx context.Context, projectRef string) (string, error)
+}
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &SettingsResource{}
 var _ resource.ResourceWithImportState = &SettingsResource{}
+var _ resource.ResourceWithModifyPlan = &SettingsResource{}
 
 func NewSettingsResource() resource.Resource {
 	return &SettingsResource{}
@@ -37,19 +110,22 @@ func NewSettingsResource() resource.Resource {
 
 // SettingsResource defines the resource implementation.
 type SettingsResource struct {
-	client *api.ClientWithResponses
+	client       *api.ClientWithResponses
+	providerData *SupabaseProviderData
 }
 
 // SettingsResourceModel describes the resource data model.
 type SettingsResourceModel struct {
-	ProjectRef types.String    `tfsdk:"project_ref"`
-	Database   *DatabaseConfig `tfsdk:"database"`
-	Pooler     *PoolerConfig   `tfsdk:"pooler"`
-	Network    *NetworkConfig  `tfsdk:"network"`
-	Storage    *StorageConfig  `tfsdk:"storage"`
-	Auth       *AuthConfig     `tfsdk:"auth"`
-	Api        *ApiConfig      `tfsdk:"api"`
-	Id         types.String    `tfsdk:"id"`
+	ProjectRef     types.String    `tfsdk:"project_ref"`
+	Database       *DatabaseConfig `tfsdk:"database"`
+	Pooler         *PoolerConfig   `tfsdk:"pooler"`
+	Network        *NetworkConfig  `tfsdk:"network"`
+	Storage        *StorageConfig  `tfsdk:"storage"`
+	Auth           *AuthConfig     `tfsdk:"auth"`
+	Api            *ApiConfig      `tfsdk:"api"`
+	Id             types.String    `tfsdk:"id"`
+	Effective      types.Object    `tfsdk:"effective"`
+	ResetOnDestroy types.Bool      `tfsdk:"reset_on_destroy"`
 }
 
 func (r *SettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -57,52 +133,79 @@ func (r *SettingsResource) Metadata(ctx context.Context, req resource.MetadataRe
 }
 
 func (r *SettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = schema.Schema{
-		MarkdownDescription: "Settings resource",
+	resp.Schema = SettingsSchema()
+}
 
-		Attributes: map[string]schema.Attribute{
-			"project_ref": schema.StringAttribute{
-				MarkdownDescription: "Project reference ID",
-				Required:            true,
-			},
-			"database": schema.SingleNestedAttribute{
-				MarkdownDescription: "Database settings as [structured configuration](https://api.supabase.com/api/v1#/v1-update-postgres-config)",
-				Optional:            true,
-				Attributes:          GetDatabaseSchemaAttributes(),
-			},
-			"pooler": schema.SingleNestedAttribute{
-				MarkdownDescription: "Connection pooler settings",
-				Optional:            true,
-				Attributes:          GetPoolerSchemaAttributes(),
-			},
-			"network": schema.SingleNestedAttribute{
-				MarkdownDescription: "Network restrictions settings",
-				Optional:            true,
-				Attributes:          GetNetworkSchemaAttributes(),
-			},
-			"storage": schema.SingleNestedAttribute{
-				MarkdownDescription: "Storage configuration settings",
-				Optional:            true,
-				Attributes:          GetStorageSchemaAttributes(),
-			},
-			"auth": schema.SingleNestedAttribute{
-				MarkdownDescription: "Auth settings as [structured configuration](https://api.supabase.com/api/v1#/v1-update-auth-service-config)",
-				Optional:            true,
-				Attributes:          GetAuthSchemaAttributes(),
-			},
-			"api": schema.SingleNestedAttribute{
-				MarkdownDescription: "API settings as [structured configuration](https://api.supabase.com/api/v1#/v1-update-postgrest-service-config)",
-				Optional:            true,
-				Attributes:          GetApiSchemaAttributes(),
+// SettingsSchema returns the schema of supabase_settings, which the resources
+// split from it need to move its state.
+func SettingsSchema() schema.Schema {
+	sections := map[string]schema.Attribute{
+		"database": schema.SingleNestedAttribute{
+			MarkdownDescription: "Database settings as [structured configuration](https://api.supabase.com/api/v1#/v1-update-postgres-config)",
+			Optional:            true,
+			Attributes:          GetDatabaseSchemaAttributes(),
+		},
+		"pooler": schema.SingleNestedAttribute{
+			MarkdownDescription: "Connection pooler settings",
+			Optional:            true,
+			Attributes:          GetPoolerSchemaAttributes(),
+		},
+		"network": schema.SingleNestedAttribute{
+			MarkdownDescription: "Network restrictions settings",
+			Optional:            true,
+			Attributes:          GetNetworkSchemaAttributes(),
+		},
+		"storage": schema.SingleNestedAttribute{
+			MarkdownDescription: "Storage configuration settings",
+			Optional:            true,
+			Attributes:          GetStorageSchemaAttributes(),
+		},
+		"auth": schema.SingleNestedAttribute{
+			MarkdownDescription: "Auth settings as [structured configuration](https://api.supabase.com/api/v1#/v1-update-auth-service-config)",
+			Optional:            true,
+			Attributes:          GetAuthSchemaAttributes(),
+		},
+		"api": schema.SingleNestedAttribute{
+			MarkdownDescription: "API settings as [structured configuration](https://api.supabase.com/api/v1#/v1-update-postgrest-service-config)",
+			Optional:            true,
+			Attributes:          GetApiSchemaAttributes(),
+		},
+	}
+
+	attributes := map[string]schema.Attribute{
+		"project_ref": schema.StringAttribute{
+			MarkdownDescription: "Project reference ID. Defaults to the provider `project_ref`.",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
 			},
-			"id": schema.StringAttribute{
-				MarkdownDescription: "Project identifier",
-				Computed:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
+		},
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Project identifier",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
 			},
 		},
+		"reset_on_destroy": schema.BoolAttribute{
+			MarkdownDescription: "Restore the documented defaults of the settings when the resource is destroyed or a setting is removed from the configuration. Credentials, values specific to the project, and settings whose default depends on the compute size or plan are left in place with a warning. Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		effectiveAttributeName: effectiveAttribute(sections),
+	}
+	for name, section := range sections {
+		attributes[name] = section
+	}
+
+	return schema.Schema{
+		MarkdownDescription: "Settings resource. The `supabase_auth_config`, `supabase_postgres_config`, `supabase_postgrest_config`, `supabase_storage_config`, `supabase_pooler_config`, and `supabase_network_restrictions` resources manage each section on its own. " +
+			"Terraform allows a single `moved` block per source address, so a `moved` block can move the state of this resource to only one of them. Import the other sections into their resources with the project reference.\n\n" +
+			"Only the settings set in the configuration are managed and refreshed. The `effective` attribute shows all settings of the configured sections as read from the API.\n\n" +
+			"Import with a project reference to import every section, or with the reference followed by a list of sections such as `mayuaycdtijbctgqbycg:auth,database` to import only those. Sections that cannot be read are skipped with a warning.",
+		Attributes: attributes,
 	}
 }
 
@@ -120,17 +223,36 @@ func (r *SettingsResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
+	resp.Diagnostics.Append(providerData.RequireManagementAPI("supabase_settings")...)
 	r.client = providerData.ManagementClient
+	r.providerData = providerData
+}
+
+// ModifyPlan falls back to the provider project_ref when the resource omits
+// it. Settings are applied to the new project in place.
+func (r *SettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.providerData == nil {
+		return
+	}
+	planProviderDefault(ctx, req, resp, "project_ref", r.providerData.ProjectRef, false)
 }
 
 func (r *SettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data SettingsResourceModel
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	var data, config SettingsResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	data.setWriteOnly(&config, nil)
+
 	if data.Database != nil {
 		resp.Diagnostics.Append(UpdateDatabaseConfig(ctx, r.client, &data)...)
 	}
@@ -157,10 +279,15 @@ func (r *SettingsResource) Create(ctx context.Context, req resource.CreateReques
 	data.Id = data.ProjectRef
 
 	tflog.Trace(ctx, "created a resource")
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setAppliedState(ctx, &resp.State, &data, req.Plan.Raw)...)
 }
 
 func (r *SettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Skipped resources keep their prior state
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
 	var data SettingsResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -192,17 +319,26 @@ func (r *SettingsResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	data.ProjectRef = data.Id
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setRefreshedState(ctx, &resp.State, &data, req.State.Raw)...)
 }
 
 func (r *SettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data SettingsResourceModel
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	var data, config, prior SettingsResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	data.setWriteOnly(&config, &prior)
+
 	if data.Database != nil {
 		resp.Diagnostics.Append(UpdateDatabaseConfig(ctx, r.client, &data)...)
 	}
@@ -226,33 +362,213 @@ func (r *SettingsResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if data.ResetOnDestroy.ValueBool() {
+		resp.Diagnostics.Append(resetRemovedSettings(ctx, r.client, req.State, req.State.Raw, req.Plan.Raw)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(setAppliedState(ctx, &resp.State, &data, req.State.Raw)...)
 }
 
 func (r *SettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// Simply fallthrough since there is no API to delete / reset settings.
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
+	var data SettingsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// There is no API to delete settings, so they are left in place unless
+	// the defaults are restored
+	if data.ResetOnDestroy.ValueBool() {
+		removed := tftypes.NewValue(req.State.Raw.Type(), nil)
+		resp.Diagnostics.Append(resetRemovedSettings(ctx, r.client, req.State, req.State.Raw, removed)...)
+	}
 }
 
-func (r *SettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	data := SettingsResourceModel{Id: types.StringValue(req.ID)}
-
-	// Initialize empty configs for import
-	data.Database = &DatabaseConfig{}
-	data.Network = &NetworkConfig{}
-	data.Api = &ApiConfig{}
-	data.Auth = &AuthConfig{}
-	data.Storage = &StorageConfig{}
-	data.Pooler = &PoolerConfig{}
-
-	// Read all configs from API when importing
-	resp.Diagnostics.Append(ReadDatabaseConfig(ctx, r.client, &data)...)
-	resp.Diagnostics.Append(ReadNetworkConfig(ctx, r.client, &data)...)
-	resp.Diagnostics.Append(ReadApiConfig(ctx, r.client, &data)...)
-	resp.Diagnostics.Append(ReadAuthConfig(ctx, r.client, &data)...)
-	resp.Diagnostics.Append(ReadStorageConfig(ctx, r.client, &data)...)
-	resp.Diagnostics.Append(ReadPoolerConfig(ctx, r.client, &data)...)
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+// settingsSection is a section of supabase_settings that can be imported or
+// reset on its own.
+type settingsSection struct {
+	name   string
+	set    func(data *SettingsResourceModel, enabled bool)
+	read   func(ctx context.Context, client *api.ClientWithResponses, state *SettingsResourceModel) diag.Diagnostics
+	update func(ctx context.Context, client *api.ClientWithResponses, plan *SettingsResourceModel) diag.Diagnostics
+	// replaced is set when update replaces the whole section, so that a reset
+	// has to send the planned values along with the defaults
+	replaced bool
+}
+
+// settingsSections are the sections of supabase_settings in the order they
+// are imported and reset.
+var settingsSections = []settingsSection{
+	{
+		name: "database",
+		set: func(data *SettingsResourceModel, enabled bool) {
+			data.Database = nil
+			if enabled {
+				data.Database = &DatabaseConfig{}
+			}
+		},
+		read:   ReadDatabaseConfig,
+		update: UpdateDatabaseConfig,
+	},
+	{
+		name: "network",
+		set: func(data *SettingsResourceModel, enabled bool) {
+			data.Network = nil
+			if enabled {
+				data.Network = &NetworkConfig{}
+			}
+		},
+		read:     ReadNetworkConfig,
+		update:   UpdateNetworkConfig,
+		replaced: true,
+	},
+	{
+		name: "api",
+		set: func(data *SettingsResourceModel, enabled bool) {
+			data.Api = nil
+			if enabled {
+				data.Api = &ApiConfig{}
+			}
+		},
+		read:   ReadApiConfig,
+		update: UpdateApiConfig,
+	},
+	{
+		name: "auth",
+		set: func(data *SettingsResourceModel, enabled bool) {
+			data.Auth = nil
+			if enabled {
+				data.Auth = &AuthConfig{}
+			}
+		},
+		read:   ReadAuthConfig,
+		update: UpdateAuthConfig,
+	},
+	{
+		name: "storage",
+		set: func(data *SettingsResourceModel, enabled bool) {
+			data.Storage = nil
+			if enabled {
+				data.Storage = &StorageConfig{}
+			}
+		},
+		read:   ReadStorageConfig,
+		update: UpdateStorageConfig,
+	},
+	{
+		name: "pooler",
+		set: func(data *SettingsResourceModel, enabled bool) {
+			data.Pooler = nil
+			if enabled {
+				data.Pooler = &PoolerConfig{}
+			}
+		},
+		read:   ReadPoolerConfig,
+		update: UpdatePoolerConfig,
+	},
+}
+
+// ParseSettingsImportID parses an import ID of supabase_settings, either a
+// project reference or a project reference followed by a colon and a comma
+// separated list of sections, such as ref:auth,database. It returns the names
+// of the sections to import, all of them when the ID lists none.
+func ParseSettingsImportID(id string) (string, []string, error) {
+	ref, list, hasList := strings.Cut(id, ":")
+	if !ProjectRefPattern.MatchString(ref) {
+		return "", nil, fmt.Errorf("expected a project reference, optionally followed by a colon and a comma separated list of sections, got: %s", id)
+	}
+
+	names := make([]string, 0, len(settingsSections))
+	if !hasList {
+		for _, section := range settingsSections {
+			names = append(names, section.name)
+		}
+		return ref, names, nil
+	}
+
+	valid := make([]string, 0, len(settingsSections))
+	for _, section := range settingsSections {
+		valid = append(valid, section.name)
+	}
+
+	seen := map[string]bool{}
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if !slices.Contains(valid, name) {
+			return "", nil, fmt.Errorf("unknown section %q in import ID %s, expected one of %s", name, id, strings.Join(valid, ", "))
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return ref, names, nil
 }
 
+// ImportState imports the sections listed in the import ID, or all of them.
+// A section that cannot be read, for example storage on a plan without the
+// entitlement, is left out of the state with a warning instead of failing the
+// import.
+func (r *SettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ref, names, err := ParseSettingsImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf("Unable to import settings: %s", err))
+		return
+	}
 
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.AddError(
+			"Unsupported in Local Mode",
+			"Settings cannot be imported when the provider is configured with mode = \"local\", since the local stack reads them from supabase/config.toml.",
+		)
+		return
+	}
+
+	data := SettingsResourceModel{
+		Id:             types.StringValue(ref),
+		ProjectRef:     types.StringValue(ref),
+		Effective:      nullEffective(resp.State),
+		ResetOnDestroy: types.BoolValue(false),
+	}
+	// Nothing is managed until the configuration sets it, so imported
+	// settings only show up in the effective attribute
+	managed := data
+
+	for _, section := range settingsSections {
+		if !slices.Contains(names, section.name) {
+			continue
+		}
+
+		section.set(&data, true)
+		diags := section.read(ctx, r.client, &data)
+		if diags.HasError() {
+			section.set(&data, false)
+			for _, d := range diags {
+				resp.Diagnostics.AddWarning(
+					fmt.Sprintf("Skipped %s settings", section.name),
+					fmt.Sprintf("%s: %s", d.Summary(), d.Detail()),
+				)
+			}
+			continue
+		}
+		resp.Diagnostics.Append(diags...)
+		section.set(&managed, true)
+	}
+
+	managedRaw, diags := encodeState(ctx, resp.State, &managed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ProjectRef = data.Id
+	resp.Diagnostics.Append(setRefreshedState(ctx, &resp.State, &data, managedRaw)...)
+}
@@ -7,4 +7,23 @@ import (
 
 func NewSettingsResource() resource.Resource {
 	return settings.NewSettingsResource()
-}
\ No newline at end of file
+}
+func NewAuthConfigResource() resource.Resource {
+	return settings.NewAuthConfigResource()
+}
+
+func NewPostgresConfigResource() resource.Resource {
+	return settings.NewPostgresConfigResource()
+}
+
+func NewPostgrestConfigResource() resource.Resource {
+	return settings.NewPostgrestConfigResource()
+}
+
+func NewStorageConfigResource() resource.Resource {
+	return settings.NewStorageConfigResource()
+}
+
+func NewPoolerConfigResource() resource.Resource {
+	return settings.NewPoolerConfigResource()
+}
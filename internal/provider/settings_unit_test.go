@@ -1,10 +1,24 @@
 package provider
 
 import (
+	"context"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
 )
 
 // Test the new struct-based model
@@ -120,4 +134,549 @@ func TestExternalProviders(t *testing.T) {
 	if model.Auth.ExternalKeycloak.Url.ValueString() != "https://keycloak.example.com" {
 		t.Errorf("Expected Keycloak URL, got %s", model.Auth.ExternalKeycloak.Url.ValueString())
 	}
-}
\ No newline at end of file
+}
+func TestParsePostgresMemory(t *testing.T) {
+	tests := []struct {
+		value    string
+		baseUnit string
+		want     float64
+		wantErr  bool
+	}{
+		{value: "128MB", baseUnit: "8kB", want: 128 * 1024},
+		{value: "4 GB", baseUnit: "kB", want: 4 * 1024 * 1024},
+		{value: "1.5MB", baseUnit: "kB", want: 1536},
+		{value: "2048B", baseUnit: "kB", want: 2},
+		{value: "16384", baseUnit: "8kB", want: 16384 * 8},
+		{value: "-1", baseUnit: "MB", want: -1024},
+		{value: "64mb", baseUnit: "kB", wantErr: true},
+		{value: "10s", baseUnit: "kB", wantErr: true},
+		{value: "MB", baseUnit: "kB", wantErr: true},
+		{value: "", baseUnit: "kB", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := settings.ParsePostgresMemory(tt.value, tt.baseUnit)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParsePostgresMemory(%q) expected an error, got %v", tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePostgresMemory(%q) returned error: %s", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePostgresMemory(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParsePostgresDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{value: "10s", want: 10000},
+		{value: "2min", want: 120000},
+		{value: "1h", want: 3600000},
+		{value: "1d", want: 86400000},
+		{value: "500", want: 500},
+		{value: "1500us", want: 1.5},
+		{value: "10m", wantErr: true},
+		{value: "10 seconds", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := settings.ParsePostgresDuration(tt.value, "ms")
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParsePostgresDuration(%q) expected an error, got %v", tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePostgresDuration(%q) returned error: %s", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePostgresDuration(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestPostgresQuantityValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator validator.String
+		value     types.String
+		wantErr   bool
+	}{
+		{name: "shared_buffers", validator: settings.PostgresMemoryBetween("8kB", 16, math.MaxInt32/2), value: types.StringValue("128MB")},
+		{name: "shared_buffers below minimum", validator: settings.PostgresMemoryBetween("8kB", 16, math.MaxInt32/2), value: types.StringValue("64kB"), wantErr: true},
+		{name: "work_mem typo", validator: settings.PostgresMemoryBetween("kB", 64, math.MaxInt32), value: types.StringValue("4MiB"), wantErr: true},
+		{name: "max_slot_wal_keep_size unlimited", validator: settings.PostgresMemoryBetween("MB", -1, math.MaxInt32), value: types.StringValue("-1")},
+		{name: "statement_timeout", validator: settings.PostgresDurationBetween("ms", 0, math.MaxInt32), value: types.StringValue("10s")},
+		{name: "statement_timeout negative", validator: settings.PostgresDurationBetween("ms", 0, math.MaxInt32), value: types.StringValue("-5s"), wantErr: true},
+		{name: "statement_timeout too long", validator: settings.PostgresDurationBetween("ms", 0, math.MaxInt32), value: types.StringValue("30d"), wantErr: true},
+		{name: "null", validator: settings.PostgresDurationBetween("ms", 0, math.MaxInt32), value: types.StringNull()},
+		{name: "unknown", validator: settings.PostgresDurationBetween("ms", 0, math.MaxInt32), value: types.StringUnknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("value"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+			tt.validator.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Errorf("expected error %t, got diagnostics: %v", tt.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestParseSettingsImportID(t *testing.T) {
+	all := []string{"database", "network", "api", "auth", "storage", "pooler"}
+
+	tests := []struct {
+		id       string
+		ref      string
+		sections []string
+		wantErr  bool
+	}{
+		{id: "mayuaycdtijbctgqbycg", ref: "mayuaycdtijbctgqbycg", sections: all},
+		{id: "mayuaycdtijbctgqbycg:auth", ref: "mayuaycdtijbctgqbycg", sections: []string{"auth"}},
+		{id: "mayuaycdtijbctgqbycg:auth,database", ref: "mayuaycdtijbctgqbycg", sections: []string{"auth", "database"}},
+		{id: "mayuaycdtijbctgqbycg:auth, auth", ref: "mayuaycdtijbctgqbycg", sections: []string{"auth"}},
+		{id: "mayuaycdtijbctgqbycg:", wantErr: true},
+		{id: "mayuaycdtijbctgqbycg:auth,postgres", wantErr: true},
+		{id: ":auth", wantErr: true},
+		{id: "", wantErr: true},
+		{id: "org/mayuaycdtijbctgqbycg", wantErr: true},
+		{id: "my-project:auth", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		ref, sections, err := settings.ParseSettingsImportID(tt.id)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseSettingsImportID(%q) expected an error", tt.id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseSettingsImportID(%q) unexpected error: %s", tt.id, err)
+			continue
+		}
+		if ref != tt.ref || !slices.Equal(sections, tt.sections) {
+			t.Errorf("ParseSettingsImportID(%q) = %s, %v, expected %s, %v", tt.id, ref, sections, tt.ref, tt.sections)
+		}
+	}
+}
+
+// TestSettingsResourceImportSections checks that only the listed sections are
+// imported, and that a section failing to read is skipped with a warning.
+func TestSettingsResourceImportSections(t *testing.T) {
+	defer gock.OffAll()
+	ctx := context.Background()
+
+	gock.New("https://api.supabase.com").
+		Get("/v1/projects/mayuaycdtijbctgqbycg/postgrest").
+		Reply(http.StatusOK).
+		JSON(api.V1PostgrestConfigResponse{
+			DbExtraSearchPath: "public,extensions",
+			DbSchema:          "public,storage,graphql_public",
+			MaxRows:           1000,
+		})
+	gock.New("https://api.supabase.com").
+		Get("/v1/projects/mayuaycdtijbctgqbycg/config/storage").
+		Reply(http.StatusPaymentRequired).
+		JSON(map[string]any{"message": "Storage config is not available on the free plan"})
+
+	client, err := api.NewClientWithResponses("https://api.supabase.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewSettingsResource()
+	configureResp := &fwresource.ConfigureResponse{}
+	r.(fwresource.ResourceWithConfigure).Configure(ctx, fwresource.ConfigureRequest{
+		ProviderData: &settings.SupabaseProviderData{ManagementClient: client},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("unable to configure: %v", configureResp.Diagnostics)
+	}
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	resp := &fwresource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	r.(fwresource.ResourceWithImportState).ImportState(ctx, fwresource.ImportStateRequest{ID: "mayuaycdtijbctgqbycg:api,storage"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unable to import: %v", resp.Diagnostics)
+	}
+	if warnings := resp.Diagnostics.Warnings(); len(warnings) != 1 || warnings[0].Severity() != diag.SeverityWarning {
+		t.Errorf("expected a warning for the storage section, got %v", resp.Diagnostics)
+	}
+	if !gock.IsDone() {
+		t.Errorf("expected only the api and storage sections to be read")
+	}
+
+	var state settings.SettingsResourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("unable to get state: %v", diags)
+	}
+	if state.Id.ValueString() != "mayuaycdtijbctgqbycg" {
+		t.Errorf("expected id mayuaycdtijbctgqbycg, got %s", state.Id)
+	}
+	if state.Api == nil || state.Storage != nil || state.Auth != nil || state.Database != nil {
+		t.Errorf("expected only the api section to be imported, got api %v, storage %v, auth %v, database %v", state.Api, state.Storage, state.Auth, state.Database)
+	}
+
+	apiSection := state.Effective.Attributes()["api"].(types.Object)
+	if got := apiSection.Attributes()["max_rows"]; !got.Equal(types.Int64Value(1000)) {
+		t.Errorf("expected effective api.max_rows 1000, got %s", got)
+	}
+}
+
+// testSettingsResource returns a supabase_settings resource using the
+// Management API at api.supabase.com, and its schema.
+func testSettingsResource(t *testing.T) (fwresource.Resource, fwresource.SchemaResponse) {
+	t.Helper()
+	ctx := context.Background()
+
+	client, err := api.NewClientWithResponses("https://api.supabase.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewSettingsResource()
+	configureResp := &fwresource.ConfigureResponse{}
+	r.(fwresource.ResourceWithConfigure).Configure(ctx, fwresource.ConfigureRequest{
+		ProviderData: &settings.SupabaseProviderData{ManagementClient: client},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("unable to configure: %v", configureResp.Diagnostics)
+	}
+
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	return r, schemaResp
+}
+
+// testSettingsState returns a supabase_settings state of model.
+func testSettingsState(t *testing.T, schemaResp fwresource.SchemaResponse, model settings.SettingsResourceModel) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	effectiveType := schemaResp.Schema.Attributes["effective"].GetType().(types.ObjectType)
+	model.ProjectRef = types.StringValue("mayuaycdtijbctgqbycg")
+	model.Id = types.StringValue("mayuaycdtijbctgqbycg")
+	model.Effective = types.ObjectNull(effectiveType.AttrTypes)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unable to set state: %v", diags)
+	}
+	return state
+}
+
+func TestSettingsResourceResetOnDestroy(t *testing.T) {
+	defer gock.OffAll()
+	ctx := context.Background()
+
+	gock.New("https://api.supabase.com").
+		Patch("/v1/projects/mayuaycdtijbctgqbycg/postgrest").
+		MatchType("json").
+		JSON(map[string]any{"max_rows": 1000}).
+		Reply(http.StatusOK).
+		JSON(api.V1PostgrestConfigResponse{MaxRows: 1000})
+	gock.New("https://api.supabase.com").
+		Patch("/v1/projects/mayuaycdtijbctgqbycg/config/auth").
+		MatchType("json").
+		JSON(map[string]any{"jwt_exp": 3600, "mfa_totp_enroll_enabled": true, "external_bitbucket_enabled": false}).
+		Reply(http.StatusOK).
+		JSON(map[string]any{"jwt_exp": 3600})
+	gock.New("https://api.supabase.com").
+		Put("/v1/projects/mayuaycdtijbctgqbycg/config/database/postgres").
+		MatchType("json").
+		JSON(map[string]any{"max_locks_per_transaction": 64}).
+		Reply(http.StatusOK).
+		JSON(map[string]any{"max_locks_per_transaction": 64})
+	// Both address families are restored, since the update replaces both
+	gock.New("https://api.supabase.com").
+		Post("/v1/projects/mayuaycdtijbctgqbycg/network-restrictions/apply").
+		MatchType("json").
+		JSON(map[string]any{"dbAllowedCidrs": []string{"0.0.0.0/0"}, "dbAllowedCidrsV6": []string{"::/0"}}).
+		Reply(http.StatusCreated).
+		JSON(map[string]any{"config": map[string]any{"dbAllowedCidrs": []string{"0.0.0.0/0"}}, "entitlement": "allowed", "status": "applied"})
+
+	r, schemaResp := testSettingsResource(t)
+	auth := &settings.AuthConfig{}
+	auth.JwtExp = types.Int64Value(7200)
+	auth.MfaTotpEnrollEnabled = types.BoolValue(false)
+	auth.ExternalBitbucket = &settings.ExternalProviderConfig{Enabled: types.BoolValue(true)}
+	state := testSettingsState(t, schemaResp, settings.SettingsResourceModel{
+		ResetOnDestroy: types.BoolValue(true),
+		Database: &settings.DatabaseConfig{
+			MaxLocksPerTransaction: types.Int64Value(128),
+			WorkMem:                types.StringValue("8MB"),
+		},
+		Network: &settings.NetworkConfig{
+			DbAllowedCidrs: []types.String{types.StringValue("203.0.113.0/24")},
+		},
+		Api: &settings.ApiConfig{
+			MaxRows: types.Int64Value(500),
+			DbPool:  types.Int64Value(20),
+		},
+		Auth: auth,
+	})
+
+	resp := &fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unable to delete: %v", resp.Diagnostics)
+	}
+	if !gock.IsDone() {
+		t.Errorf("expected every setting with a default to be reset, %d requests are pending", len(gock.Pending()))
+	}
+
+	// db_pool and work_mem depend on the compute size
+	warnings := resp.Diagnostics.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Detail(), "database.work_mem, api.db_pool") {
+		t.Errorf("expected a warning for database.work_mem and api.db_pool, got %v", resp.Diagnostics)
+	}
+}
+
+// TestSettingsDefaultsCoverage checks that every setting that a reset can
+// restore has a default, or is explicitly left in place.
+func TestSettingsDefaultsCoverage(t *testing.T) {
+	ctx := context.Background()
+
+	for _, section := range []string{"database", "network", "api", "auth", "storage", "pooler"} {
+		attribute, ok := settings.SettingsSchema().Attributes[section].(schema.SingleNestedAttribute)
+		if !ok {
+			t.Fatalf("expected %s to be a nested attribute", section)
+		}
+
+		for name, nested := range attribute.Attributes {
+			if nested.IsWriteOnly() || strings.HasSuffix(name, "_wo_version") {
+				continue
+			}
+
+			value, hasDefault := settings.SettingDefault(section, name)
+			kept := settings.SettingKeptOnReset(section, name)
+			switch {
+			case hasDefault && kept:
+				t.Errorf("%s.%s has a default but is also left in place", section, name)
+			case !hasDefault && !kept:
+				t.Errorf("%s.%s has no default and is not listed as left in place", section, name)
+			case hasDefault && !value.Type(ctx).Equal(nested.GetType()):
+				t.Errorf("%s.%s has a default of type %s, expected %s", section, name, value.Type(ctx), nested.GetType())
+			}
+		}
+	}
+}
+
+func TestSettingsResourceResetRemovedSettings(t *testing.T) {
+	defer gock.OffAll()
+	ctx := context.Background()
+
+	r, schemaResp := testSettingsResource(t)
+	prior := testSettingsState(t, schemaResp, settings.SettingsResourceModel{
+		Api: &settings.ApiConfig{
+			DbSchema: types.StringValue("public,storage"),
+			MaxRows:  types.Int64Value(500),
+		},
+		Pooler: &settings.PoolerConfig{
+			DefaultPoolSize: types.Int64Value(30),
+		},
+	})
+	planModel := settings.SettingsResourceModel{
+		Api: &settings.ApiConfig{
+			DbSchema: types.StringValue("public,storage"),
+		},
+	}
+
+	tests := []struct {
+		name  string
+		reset bool
+	}{
+		{name: "disabled", reset: false},
+		{name: "enabled", reset: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.OffAll()
+
+			gock.New("https://api.supabase.com").
+				Patch("/v1/projects/mayuaycdtijbctgqbycg/postgrest").
+				MatchType("json").
+				JSON(map[string]any{"db_schema": "public,storage"}).
+				Reply(http.StatusOK).
+				JSON(api.V1PostgrestConfigResponse{DbSchema: "public,storage", MaxRows: 500})
+			if tt.reset {
+				gock.New("https://api.supabase.com").
+					Patch("/v1/projects/mayuaycdtijbctgqbycg/postgrest").
+					MatchType("json").
+					JSON(map[string]any{"max_rows": 1000}).
+					Reply(http.StatusOK).
+					JSON(api.V1PostgrestConfigResponse{DbSchema: "public,storage", MaxRows: 1000})
+				gock.New("https://api.supabase.com").
+					Patch("/v1/projects/mayuaycdtijbctgqbycg/config/database/pooler").
+					MatchType("json").
+					JSON(map[string]any{"default_pool_size": nil}).
+					Reply(http.StatusOK).
+					JSON(map[string]any{"default_pool_size": 15})
+			}
+
+			planModel.ResetOnDestroy = types.BoolValue(tt.reset)
+			plan := testSettingsState(t, schemaResp, planModel)
+
+			resp := &fwresource.UpdateResponse{State: prior}
+			r.Update(ctx, fwresource.UpdateRequest{
+				State:  prior,
+				Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+			}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unable to update: %v", resp.Diagnostics)
+			}
+			if !gock.IsDone() {
+				t.Errorf("expected only the planned requests, %d are pending", len(gock.Pending()))
+			}
+			if gock.HasUnmatchedRequest() {
+				t.Errorf("unexpected requests: %v", gock.GetUnmatchedRequests())
+			}
+		})
+	}
+}
+
+// TestSettingsResourceWriteOnlySecrets checks that write-only secrets are sent
+// on create and when their version changes only.
+func TestSettingsResourceWriteOnlySecrets(t *testing.T) {
+	defer gock.OffAll()
+	ctx := context.Background()
+
+	r, schemaResp := testSettingsResource(t)
+
+	authModel := func(secret string, version int64) settings.SettingsResourceModel {
+		model := settings.SettingsResourceModel{
+			ResetOnDestroy: types.BoolValue(false),
+			Auth:           &settings.AuthConfig{},
+		}
+		model.Auth.JwtExp = types.Int64Value(3600)
+		model.Auth.SmtpPassWoVersion = types.Int64Value(version)
+		model.Auth.SmtpPassWo = types.StringNull()
+		if secret != "" {
+			model.Auth.SmtpPassWo = types.StringValue(secret)
+		}
+		return model
+	}
+
+	expectAuthUpdate := func(body map[string]any) {
+		gock.New("https://api.supabase.com").
+			Patch("/v1/projects/mayuaycdtijbctgqbycg/config/auth").
+			MatchType("json").
+			JSON(body).
+			Reply(http.StatusOK).
+			JSON(map[string]any{"jwt_exp": 3600})
+	}
+
+	t.Run("create", func(t *testing.T) {
+		defer gock.OffAll()
+		expectAuthUpdate(map[string]any{"jwt_exp": 3600, "smtp_pass": "s3cret"})
+
+		// Terraform never plans write-only values
+		plan := testSettingsState(t, schemaResp, authModel("", 1))
+		config := testSettingsState(t, schemaResp, authModel("s3cret", 1))
+
+		resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}}
+		r.Create(ctx, fwresource.CreateRequest{
+			Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+			Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw},
+		}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unable to create: %v", resp.Diagnostics)
+		}
+		if !gock.IsDone() {
+			t.Errorf("expected the secret to be sent")
+		}
+	})
+
+	t.Run("external provider", func(t *testing.T) {
+		defer gock.OffAll()
+		expectAuthUpdate(map[string]any{"jwt_exp": 3600, "external_apple_secret": "s3cret"})
+
+		appleModel := func(secret string) settings.SettingsResourceModel {
+			model := authModel("", 1)
+			model.Auth.SmtpPassWoVersion = types.Int64Null()
+			model.Auth.ExternalApple = &settings.ExternalProviderConfig{
+				SecretWo:        types.StringNull(),
+				SecretWoVersion: types.Int64Value(1),
+			}
+			if secret != "" {
+				model.Auth.ExternalApple.SecretWo = types.StringValue(secret)
+			}
+			return model
+		}
+		plan := testSettingsState(t, schemaResp, appleModel(""))
+		config := testSettingsState(t, schemaResp, appleModel("s3cret"))
+
+		resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)}}
+		r.Create(ctx, fwresource.CreateRequest{
+			Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+			Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw},
+		}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unable to create: %v", resp.Diagnostics)
+		}
+		if !gock.IsDone() {
+			t.Errorf("expected the Apple secret to be sent")
+		}
+	})
+
+	tests := []struct {
+		name    string
+		version int64
+		body    map[string]any
+	}{
+		{name: "unchanged version", version: 1, body: map[string]any{"jwt_exp": 3600}},
+		{name: "changed version", version: 2, body: map[string]any{"jwt_exp": 3600, "smtp_pass": "s3cret"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.OffAll()
+			expectAuthUpdate(tt.body)
+
+			prior := testSettingsState(t, schemaResp, authModel("", 1))
+			plan := testSettingsState(t, schemaResp, authModel("", tt.version))
+			config := testSettingsState(t, schemaResp, authModel("s3cret", tt.version))
+
+			resp := &fwresource.UpdateResponse{State: prior}
+			r.Update(ctx, fwresource.UpdateRequest{
+				State:  prior,
+				Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw},
+			}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unable to update: %v", resp.Diagnostics)
+			}
+			if !gock.IsDone() || gock.HasUnmatchedRequest() {
+				t.Errorf("expected an update with %v, got unmatched requests %v", tt.body, gock.GetUnmatchedRequests())
+			}
+		})
+	}
+}
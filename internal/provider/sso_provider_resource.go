@@ -15,13 +15,15 @@ import (
 )
 
 var _ resource.Resource = &SsoProviderResource{}
+var _ resource.ResourceWithModifyPlan = &SsoProviderResource{}
 
 func NewSsoProviderResource() resource.Resource {
 	return &SsoProviderResource{}
 }
 
 type SsoProviderResource struct {
-	client *api.ClientWithResponses
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
 }
 
 type SsoProviderResourceModel struct {
@@ -45,9 +47,11 @@ func (r *SsoProviderResource) Schema(ctx context.Context, req resource.SchemaReq
 		MarkdownDescription: "Supabase SSO Provider resource",
 		Attributes: map[string]schema.Attribute{
 			"project_ref": schema.StringAttribute{
-				MarkdownDescription: "Project reference",
-				Required:            true,
+				MarkdownDescription: "Project reference. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -109,10 +113,22 @@ func (r *SsoProviderResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
+	resp.Diagnostics.Append(providerData.RequireManagementAPI("supabase_sso_provider")...)
 	r.client = providerData.ManagementClient
+	r.providerData = providerData
+}
+
+// ModifyPlan falls back to the provider project_ref when the resource omits it.
+func (r *SsoProviderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.PlanProjectRef(ctx, req, resp)
 }
 
 func (r *SsoProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(settings.SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
 	var data SsoProviderResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -184,6 +200,11 @@ func (r *SsoProviderResource) Create(ctx context.Context, req resource.CreateReq
 }
 
 func (r *SsoProviderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Skipped resources keep their prior state
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
 	var data SsoProviderResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -235,6 +256,11 @@ func (r *SsoProviderResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *SsoProviderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(settings.SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
 	var data SsoProviderResourceModel
 	var state SsoProviderResourceModel
 
@@ -306,6 +332,10 @@ func (r *SsoProviderResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *SsoProviderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
 	var data SsoProviderResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -19,7 +19,8 @@ func NewSsoProvidersDataSource() datasource.DataSource {
 }
 
 type SsoProvidersDataSource struct {
-	client *api.ClientWithResponses
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
 }
 
 type SsoProvidersDataSourceModel struct {
@@ -44,8 +45,9 @@ func (d *SsoProvidersDataSource) Schema(ctx context.Context, req datasource.Sche
 		MarkdownDescription: "Retrieve a list of SSO providers for a Supabase project",
 		Attributes: map[string]schema.Attribute{
 			"project_ref": schema.StringAttribute{
-				MarkdownDescription: "Project reference",
-				Required:            true,
+				MarkdownDescription: "Project reference. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"providers": schema.ListNestedAttribute{
 				MarkdownDescription: "List of SSO providers",
@@ -94,16 +96,27 @@ func (d *SsoProvidersDataSource) Configure(ctx context.Context, req datasource.C
 		return
 	}
 
+	resp.Diagnostics.Append(providerData.RequireManagementAPI("supabase_sso_providers")...)
 	d.client = providerData.ManagementClient
+	d.providerData = providerData
 }
 
 func (d *SsoProvidersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Skipped data sources return their configuration
+	if d.providerData.SkipsManagementAPI() {
+		return
+	}
+
 	var data SsoProvidersDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(d.providerData.ResolveProjectRef(&data.ProjectRef)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	httpResp, err := d.client.V1ListAllSsoProviderWithResponse(ctx, data.ProjectRef.ValueString())
 	if err != nil {
@@ -6,21 +6,24 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
-	"github.com/supabase/cli/pkg/fetcher"
 	"github.com/supabase/cli/pkg/storage"
 )
 
 var (
-	_ resource.Resource              = &StorageBucketResource{}
-	_ resource.ResourceWithConfigure = &StorageBucketResource{}
+	_ resource.Resource                = &StorageBucketResource{}
+	_ resource.ResourceWithConfigure   = &StorageBucketResource{}
+	_ resource.ResourceWithImportState = &StorageBucketResource{}
+	_ resource.ResourceWithModifyPlan  = &StorageBucketResource{}
 )
 
 func NewStorageBucketResource() resource.Resource {
@@ -42,6 +45,7 @@ type StorageBucketResourceModel struct {
 	CreatedAt        types.String `tfsdk:"created_at"`
 	UpdatedAt        types.String `tfsdk:"updated_at"`
 	Owner            types.String `tfsdk:"owner"`
+	ForceDestroy     types.Bool   `tfsdk:"force_destroy"`
 }
 
 
@@ -70,12 +74,22 @@ resource "supabase_storage_bucket" "example" {
   allowed_mime_types = ["image/*", "video/mp4"]
 }
 ~~~
+
+## Import
+
+Buckets can be imported using the project reference and bucket name:
+
+~~~shell
+terraform import supabase_storage_bucket.example abcdefghijklmnopqrst/my-bucket
+~~~
 `,
 		Attributes: map[string]schema.Attribute{
 			"project_ref": schema.StringAttribute{
-				MarkdownDescription: "Project reference ID",
-				Required:            true,
+				MarkdownDescription: "Project reference ID. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -122,6 +136,12 @@ resource "supabase_storage_bucket" "example" {
 				MarkdownDescription: "Bucket owner ID",
 				Computed:            true,
 			},
+			"force_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether to delete all objects in the bucket when the bucket is destroyed. Without it, destroying a bucket that still contains objects fails.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -143,6 +163,11 @@ func (r *StorageBucketResource) Configure(ctx context.Context, req resource.Conf
 	r.providerData = providerData
 }
 
+// ModifyPlan falls back to the provider project_ref when the resource omits it.
+func (r *StorageBucketResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.PlanProjectRef(ctx, req, resp)
+}
+
 func (r *StorageBucketResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data StorageBucketResourceModel
 
@@ -300,6 +325,13 @@ func (r *StorageBucketResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	if data.ForceDestroy.ValueBool() {
+		if err := emptyStorageBucket(ctx, storageClient, data.Name.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Storage API Error", fmt.Sprintf("Unable to empty storage bucket: %s", err))
+			return
+		}
+	}
+
 	// Delete the bucket via CLI
 	_, err = storageClient.DeleteBucket(ctx, data.Name.ValueString())
 	if err != nil && !strings.Contains(err.Error(), "404") {
@@ -308,25 +340,66 @@ func (r *StorageBucketResource) Delete(ctx context.Context, req resource.DeleteR
 	}
 }
 
-// Helper methods for CLI-based storage operations
-func (r *StorageBucketResource) getStorageClient(ctx context.Context, projectRef string) (*storage.StorageAPI, error) {
-	// Get service role token using same pattern as existing implementation
-	serviceRoleToken, err := r.providerData.TokenManager.GetServiceRoleToken(ctx, projectRef)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get service role token: %w", err)
+func (r *StorageBucketResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the format project_ref/bucket_name, got: %s", req.ID),
+		)
+		return
 	}
 
-	// Create storage client similar to how CLI does it
-	storageURL := fmt.Sprintf("https://%s.supabase.co", projectRef)
-	client := &storage.StorageAPI{
-		Fetcher: fetcher.NewFetcher(
-			storageURL,
-			fetcher.WithBearerToken(serviceRoleToken),
-			fetcher.WithUserAgent("terraform-provider-supabase"),
-		),
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_ref"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
+}
+
+// emptyStorageBucket deletes every object in a bucket. Each folder is listed
+// page by page and its objects are deleted in batches, so that large buckets
+// never need a single long running request.
+func emptyStorageBucket(ctx context.Context, storageClient *storage.StorageAPI, bucket string) error {
+	dirs := []string{""}
+
+	for len(dirs) > 0 {
+		dir := dirs[0]
+		dirs = dirs[1:]
+
+		var keys []string
+		for page := 0; ; page++ {
+			entries, err := storageClient.ListObjects(ctx, bucket, dir, page)
+			if err != nil {
+				if strings.Contains(err.Error(), "404") {
+					return nil
+				}
+				return fmt.Errorf("failed to list objects: %w", err)
+			}
+
+			for _, entry := range entries {
+				if entry.Id == nil {
+					// Folders are returned without an id
+					dirs = append(dirs, dir+entry.Name+"/")
+					continue
+				}
+				keys = append(keys, dir+entry.Name)
+			}
+
+			if len(entries) < storage.PAGE_LIMIT {
+				break
+			}
+		}
+
+		if err := deleteStorageObjects(ctx, storageClient, bucket, keys); err != nil {
+			return fmt.Errorf("failed to delete objects: %w", err)
+		}
 	}
 
-	return client, nil
+	return nil
+}
+
+// Helper methods for CLI-based storage operations
+func (r *StorageBucketResource) getStorageClient(ctx context.Context, projectRef string) (*storage.StorageAPI, error) {
+	return newStorageClient(ctx, r.providerData, projectRef)
 }
 
 func (r *StorageBucketResource) getBucket(ctx context.Context, storageClient *storage.StorageAPI, bucketId string) (*storage.BucketResponse, error) {
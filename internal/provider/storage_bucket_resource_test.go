@@ -112,6 +112,13 @@ func TestAccStorageBucketResource(t *testing.T) {
 					resource.TestCheckResourceAttr("supabase_storage_bucket.test", "file_size_limit", "104857600"),
 				),
 			},
+			// ImportState testing
+			{
+				ResourceName:      "supabase_storage_bucket.test",
+				ImportState:       true,
+				ImportStateId:     "mayuaycdtijbctgqbycg/test-bucket",
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
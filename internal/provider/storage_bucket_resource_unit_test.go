@@ -2,10 +2,13 @@ package provider
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/supabase/cli/pkg/fetcher"
 	"github.com/supabase/cli/pkg/storage"
+	"gopkg.in/h2non/gock.v1"
 )
 
 func TestStorageBucketResourceUpdateDataFromBucket(t *testing.T) {
@@ -180,4 +183,64 @@ func TestStorageBucketResourceDeleteErrorHandling(t *testing.T) {
 			// with mocked HTTP responses returning these errors
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestEmptyStorageBucket(t *testing.T) {
+	defer gock.OffAll()
+
+	page := make([]map[string]interface{}, storage.PAGE_LIMIT)
+	keys := make([]string, storage.PAGE_LIMIT)
+	for i := range page {
+		name := string(rune('a'+i%26)) + string(rune('a'+i/26)) + ".png"
+		page[i] = map[string]interface{}{"name": name, "id": "object-" + name}
+		keys[i] = name
+	}
+	page[0] = map[string]interface{}{"name": "avatars", "id": nil}
+	// The folder is listed separately and the root continues on a second page
+	keys = append(keys[1:], "last.png")
+
+	gock.New("https://mayuaycdtijbctgqbycg.supabase.co").
+		Post("/storage/v1/object/list/assets").
+		MatchType("json").
+		JSON(storage.ListObjectsQuery{Prefix: "", Limit: storage.PAGE_LIMIT, Offset: 0}).
+		Reply(http.StatusOK).
+		JSON(page)
+
+	gock.New("https://mayuaycdtijbctgqbycg.supabase.co").
+		Post("/storage/v1/object/list/assets").
+		MatchType("json").
+		JSON(storage.ListObjectsQuery{Prefix: "", Limit: storage.PAGE_LIMIT, Offset: storage.PAGE_LIMIT}).
+		Reply(http.StatusOK).
+		JSON([]map[string]interface{}{{"name": "last.png", "id": "object-last"}})
+
+	gock.New("https://mayuaycdtijbctgqbycg.supabase.co").
+		Delete("/storage/v1/object/assets").
+		MatchType("json").
+		JSON(map[string][]string{"prefixes": keys}).
+		Reply(http.StatusOK).
+		JSON([]interface{}{})
+
+	gock.New("https://mayuaycdtijbctgqbycg.supabase.co").
+		Post("/storage/v1/object/list/assets").
+		MatchType("json").
+		JSON(storage.ListObjectsQuery{Prefix: "avatars/", Limit: storage.PAGE_LIMIT, Offset: 0}).
+		Reply(http.StatusOK).
+		JSON([]map[string]interface{}{{"name": "user.png", "id": "object-user"}})
+
+	gock.New("https://mayuaycdtijbctgqbycg.supabase.co").
+		Delete("/storage/v1/object/assets").
+		MatchType("json").
+		JSON(map[string][]string{"prefixes": {"avatars/user.png"}}).
+		Reply(http.StatusOK).
+		JSON([]interface{}{})
+
+	client := &storage.StorageAPI{Fetcher: fetcher.NewFetcher("https://mayuaycdtijbctgqbycg.supabase.co")}
+
+	if err := emptyStorageBucket(context.Background(), client, "assets"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if !gock.IsDone() {
+		t.Errorf("Expected all list and delete requests to be made")
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
 	"github.com/supabase/cli/pkg/api"
@@ -21,7 +22,8 @@ func NewStorageBucketsDataSource() datasource.DataSource {
 }
 
 type StorageBucketsDataSource struct {
-	client *api.ClientWithResponses
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
 }
 
 type StorageBucketsDataSourceModel struct {
@@ -59,8 +61,9 @@ data "supabase_storage_buckets" "all" {
 `,
 		Attributes: map[string]schema.Attribute{
 			"project_ref": schema.StringAttribute{
-				MarkdownDescription: "Project reference ID",
-				Required:            true,
+				MarkdownDescription: "Project reference ID. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Same as project_ref",
@@ -117,6 +120,7 @@ func (d *StorageBucketsDataSource) Configure(ctx context.Context, req datasource
 	}
 
 	d.client = providerData.ManagementClient
+	d.providerData = providerData
 }
 
 func (d *StorageBucketsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -126,6 +130,21 @@ func (d *StorageBucketsDataSource) Read(ctx context.Context, req datasource.Read
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(d.providerData.ResolveProjectRef(&data.ProjectRef)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The local stack has no Management API, so list through storage instead
+	if d.providerData != nil && d.providerData.Mode == settings.ModeLocal {
+		resp.Diagnostics.Append(d.readLocal(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 
 	// Call the API to list all buckets
 	response, err := d.client.V1ListAllBucketsWithResponse(ctx, data.ProjectRef.ValueString())
@@ -164,4 +183,32 @@ func (d *StorageBucketsDataSource) Read(ctx context.Context, req datasource.Read
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-}
\ No newline at end of file
+}
+
+func (d *StorageBucketsDataSource) readLocal(ctx context.Context, data *StorageBucketsDataSourceModel) diag.Diagnostics {
+	storageClient, err := newStorageClient(ctx, d.providerData, data.ProjectRef.ValueString())
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Storage Client Error", fmt.Sprintf("Unable to create storage client: %s", err))}
+	}
+
+	buckets, err := storageClient.ListBuckets(ctx)
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Storage API Error", fmt.Sprintf("Unable to list storage buckets: %s", err))}
+	}
+
+	data.Id = data.ProjectRef
+	data.Buckets = make([]StorageBucketModel, 0, len(buckets))
+
+	for _, bucket := range buckets {
+		data.Buckets = append(data.Buckets, StorageBucketModel{
+			Id:        types.StringValue(bucket.Id),
+			Name:      types.StringValue(bucket.Name),
+			Owner:     types.StringValue(bucket.Owner),
+			Public:    types.BoolValue(bucket.Public),
+			CreatedAt: types.StringValue(bucket.CreatedAt),
+			UpdatedAt: types.StringValue(bucket.UpdatedAt),
+		})
+	}
+
+	return nil
+}
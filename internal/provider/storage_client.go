@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/fetcher"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+// storageURLPlaceholder is replaced with the project reference in the
+// provider level storage_url template.
+const storageURLPlaceholder = "{ref}"
+
+// StorageEndpointResolver resolves the Storage API URL of a project. A
+// configured template takes precedence, then an active custom hostname, and
+// finally the default supabase.co domain.
+type StorageEndpointResolver struct {
+	managementClient *api.ClientWithResponses
+	template         string
+
+	// Cache for custom hostnames, empty when a project has none
+	hostnames map[string]string
+	mutex     sync.RWMutex
+}
+
+// NewStorageEndpointResolver creates a new storage endpoint resolver
+func NewStorageEndpointResolver(managementClient *api.ClientWithResponses, template string) *StorageEndpointResolver {
+	return &StorageEndpointResolver{
+		managementClient: managementClient,
+		template:         template,
+		hostnames:        make(map[string]string),
+	}
+}
+
+// StorageURL returns the base URL of the Storage API for a project
+func (r *StorageEndpointResolver) StorageURL(ctx context.Context, projectRef string) (string, error) {
+	if r.template != "" {
+		return strings.TrimSuffix(strings.ReplaceAll(r.template, storageURLPlaceholder, projectRef), "/"), nil
+	}
+
+	if hostname := r.customHostname(ctx, projectRef); hostname != "" {
+		return "https://" + hostname, nil
+	}
+
+	return fmt.Sprintf("https://%s.supabase.co", projectRef), nil
+}
+
+// customHostname returns the custom hostname of a project once it serves
+// traffic. Lookup failures fall back to the default domain rather than
+// failing storage operations.
+func (r *StorageEndpointResolver) customHostname(ctx context.Context, projectRef string) string {
+	r.mutex.RLock()
+	hostname, exists := r.hostnames[projectRef]
+	r.mutex.RUnlock()
+	if exists {
+		return hostname
+	}
+
+	httpResp, err := r.managementClient.V1GetHostnameConfigWithResponse(ctx, projectRef)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("unable to resolve custom hostname for %s: %s", projectRef, err))
+		return ""
+	}
+
+	if httpResp.StatusCode() == http.StatusOK && httpResp.JSON200 != nil && httpResp.JSON200.Status == api.N5ServicesReconfigured {
+		hostname = httpResp.JSON200.CustomHostname
+	}
+
+	r.mutex.Lock()
+	r.hostnames[projectRef] = hostname
+	r.mutex.Unlock()
+
+	return hostname
+}
+
+// serviceRoleAuthTransport authenticates data-plane requests with the service
+// role key of a project. When a request is rejected with 401 or 403, the key
+// may have been rotated, so the cached key is dropped and the request is
+// retried once with a freshly fetched key.
+type serviceRoleAuthTransport struct {
+	base         http.RoundTripper // Defaults to http.DefaultTransport when nil
+	tokenManager settings.TokenManager
+	projectRef   string
+}
+
+func (t *serviceRoleAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	ctx := req.Context()
+	token, err := t.tokenManager.GetServiceRoleToken(ctx, t.projectRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service role token: %w", err)
+	}
+
+	resp, err := base.RoundTrip(withBearerToken(req, token))
+	if err != nil || (resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden) {
+		return resp, err
+	}
+
+	// Requests with a body can only be replayed when it can be rewound
+	retry := req
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return resp, nil
+		}
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return resp, nil
+		}
+		retry = req.Clone(ctx)
+		retry.Body = body
+	}
+
+	t.tokenManager.InvalidateProjectTokens(t.projectRef)
+	fresh, err := t.tokenManager.GetServiceRoleToken(ctx, t.projectRef)
+	if err != nil || fresh == token {
+		// The key was not rotated, so the rejection stands
+		return resp, nil
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("service role key for %s was rejected, retrying with a refreshed key", t.projectRef))
+
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+
+	return base.RoundTrip(withBearerToken(retry, fresh))
+}
+
+// withBearerToken returns a copy of req authenticated with token. The body is
+// shared with req.
+func withBearerToken(req *http.Request, token string) *http.Request {
+	authorized := req.Clone(req.Context())
+	authorized.Body = req.Body
+	authorized.Header.Set("Authorization", "Bearer "+token)
+	return authorized
+}
+
+// newStorageClient builds a Storage API client for a project, authenticated
+// with the project's service role key. It is shared by all storage resources.
+func newStorageClient(ctx context.Context, providerData *settings.SupabaseProviderData, projectRef string) (*storage.StorageAPI, error) {
+	storageURL := fmt.Sprintf("https://%s.supabase.co", projectRef)
+	if providerData.StorageEndpoints != nil {
+		var err error
+		storageURL, err = providerData.StorageEndpoints.StorageURL(ctx, projectRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve storage URL: %w", err)
+		}
+	}
+
+	httpClient := providerData.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	options := []fetcher.FetcherOption{
+		fetcher.WithUserAgent("terraform-provider-supabase"),
+	}
+	if providerData.ServiceRoleKey != "" {
+		// A configured key is used as is, there is nothing to refresh
+		options = append(options,
+			fetcher.WithBearerToken(providerData.ServiceRoleKey),
+			fetcher.WithHTTPClient(httpClient),
+		)
+	} else {
+		// Fail early when the key cannot be fetched at all
+		if _, err := providerData.TokenManager.GetServiceRoleToken(ctx, projectRef); err != nil {
+			return nil, fmt.Errorf("failed to get service role token: %w", err)
+		}
+		options = append(options, fetcher.WithHTTPClient(&http.Client{
+			Timeout: httpClient.Timeout,
+			Transport: &serviceRoleAuthTransport{
+				base:         httpClient.Transport,
+				tokenManager: providerData.TokenManager,
+				projectRef:   projectRef,
+			},
+		}))
+	}
+
+	// Create storage client similar to how CLI does it
+	client := &storage.StorageAPI{
+		Fetcher: fetcher.NewFetcher(storageURL, options...),
+	}
+
+	return client, nil
+}
@@ -0,0 +1,679 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+var (
+	_ resource.Resource               = &StorageDirectorySyncResource{}
+	_ resource.ResourceWithConfigure  = &StorageDirectorySyncResource{}
+	_ resource.ResourceWithModifyPlan = &StorageDirectorySyncResource{}
+)
+
+func NewStorageDirectorySyncResource() resource.Resource {
+	return &StorageDirectorySyncResource{}
+}
+
+// StorageDirectorySyncResource mirrors a local directory into a bucket prefix.
+type StorageDirectorySyncResource struct {
+	providerData *settings.SupabaseProviderData
+}
+
+type StorageDirectorySyncResourceModel struct {
+	ProjectRef    types.String `tfsdk:"project_ref"`
+	Id            types.String `tfsdk:"id"`
+	Bucket        types.String `tfsdk:"bucket"`
+	Prefix        types.String `tfsdk:"prefix"`
+	SourceDir     types.String `tfsdk:"source_dir"`
+	Include       types.List   `tfsdk:"include"`
+	Exclude       types.List   `tfsdk:"exclude"`
+	DeleteRemoved types.Bool   `tfsdk:"delete_removed"`
+	Parallelism   types.Int64  `tfsdk:"parallelism"`
+	CacheControl  types.String `tfsdk:"cache_control"`
+	Files         types.Map    `tfsdk:"files"`
+}
+
+func (r *StorageDirectorySyncResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_storage_directory_sync"
+}
+
+func (r *StorageDirectorySyncResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+
+Mirrors a local directory into a prefix of a Supabase storage bucket.
+
+Only files whose MD5 differs from the ETag of the remote object are uploaded. Uploads run concurrently, up to ` + "`parallelism`" + ` at a time. Remote objects under the prefix that no longer exist locally are deleted when ` + "`delete_removed`" + ` is set.
+
+Globs in ` + "`include`" + ` and ` + "`exclude`" + ` are matched against paths relative to ` + "`source_dir`" + `, using ` + "`/`" + ` as separator. ` + "`*`" + ` matches within a path segment and ` + "`**`" + ` matches across segments.
+
+## Example Usage
+
+~~~hcl
+resource "supabase_storage_directory_sync" "static" {
+  project_ref    = "abcdefghijklmnopqrst"
+  bucket         = "static"
+  prefix         = "site/"
+  source_dir     = "${path.module}/dist"
+  exclude        = ["**/*.map"]
+  delete_removed = true
+  parallelism    = 16
+}
+~~~
+`,
+		Attributes: map[string]schema.Attribute{
+			"project_ref": schema.StringAttribute{
+				MarkdownDescription: "Project reference ID. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Sync identifier in the format `bucket/prefix`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "Name of the bucket to sync into",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"prefix": schema.StringAttribute{
+				MarkdownDescription: "Folder within the bucket that mirrors the directory, with or without a trailing slash. Defaults to the bucket root.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_dir": schema.StringAttribute{
+				MarkdownDescription: "Local directory to mirror",
+				Required:            true,
+			},
+			"include": schema.ListAttribute{
+				MarkdownDescription: "Globs of files to include. All files are included when omitted.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"exclude": schema.ListAttribute{
+				MarkdownDescription: "Globs of files to exclude, applied after `include`",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"delete_removed": schema.BoolAttribute{
+				MarkdownDescription: "Whether to delete remote objects under the prefix that do not exist locally. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"parallelism": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of concurrent uploads. Defaults to `8`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(8),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 64),
+				},
+			},
+			"cache_control": schema.StringAttribute{
+				MarkdownDescription: "Cache-Control header served with the uploaded objects. Defaults to `max-age=3600`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("max-age=3600"),
+			},
+			"files": schema.MapAttribute{
+				MarkdownDescription: "Hex encoded MD5 of each synced file, keyed by path relative to `source_dir`",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *StorageDirectorySyncResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*settings.SupabaseProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *settings.SupabaseProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// ModifyPlan falls back to the provider project_ref, and hashes the local
+// directory so that added, changed, and removed files show up in the plan.
+func (r *StorageDirectorySyncResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	r.providerData.PlanProjectRef(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan StorageDirectorySyncResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.SourceDir.IsUnknown() || plan.Include.IsUnknown() || plan.Exclude.IsUnknown() {
+		plan.Files = types.MapUnknown(types.StringType)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	files, diags := r.localFiles(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hashes := make(map[string]string, len(files))
+	for rel, file := range files {
+		hashes[rel] = file.md5
+	}
+
+	plan.Files, diags = types.MapValueFrom(ctx, types.StringType, hashes)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *StorageDirectorySyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data StorageDirectorySyncResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(data.Bucket.ValueString() + "/" + storageObjectPrefix(data.Prefix.ValueString()))
+	resp.Diagnostics.Append(r.sync(ctx, &data, map[string]string{})...)
+
+	tflog.Trace(ctx, "synced storage directory")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StorageDirectorySyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data StorageDirectorySyncResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	storageClient, err := newStorageClient(ctx, r.providerData, data.ProjectRef.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Storage Client Error", fmt.Sprintf("Unable to create storage client: %s", err))
+		return
+	}
+
+	remote, err := listStorageObjectsRecursive(ctx, storageClient, data.Bucket.ValueString(), storageObjectPrefix(data.Prefix.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Storage API Error", fmt.Sprintf("Unable to list storage objects: %s", err))
+		return
+	}
+
+	previous := map[string]string{}
+	resp.Diagnostics.Append(data.Files.ElementsAs(ctx, &previous, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Track the remote state of the managed files, and of every object under
+	// the prefix when removed files are deleted, so that drift shows up as a
+	// diff against the local directory.
+	files := make(map[string]string, len(previous))
+	for rel, hash := range remote {
+		if _, managed := previous[rel]; !managed && !data.DeleteRemoved.ValueBool() {
+			continue
+		}
+		if hash == "" {
+			// Multipart uploads have no MD5 ETag, so trust the last sync.
+			hash = previous[rel]
+		}
+		files[rel] = hash
+	}
+
+	var diags diag.Diagnostics
+	data.Files, diags = types.MapValueFrom(ctx, types.StringType, files)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StorageDirectorySyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data StorageDirectorySyncResourceModel
+	var state StorageDirectorySyncResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous := map[string]string{}
+	resp.Diagnostics.Append(state.Files.ElementsAs(ctx, &previous, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = state.Id
+	resp.Diagnostics.Append(r.sync(ctx, &data, previous)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StorageDirectorySyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data StorageDirectorySyncResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	files := map[string]string{}
+	resp.Diagnostics.Append(data.Files.ElementsAs(ctx, &files, false)...)
+	if resp.Diagnostics.HasError() || len(files) == 0 {
+		return
+	}
+
+	storageClient, err := newStorageClient(ctx, r.providerData, data.ProjectRef.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Storage Client Error", fmt.Sprintf("Unable to create storage client: %s", err))
+		return
+	}
+
+	prefix := storageObjectPrefix(data.Prefix.ValueString())
+	keys := make([]string, 0, len(files))
+	for rel := range files {
+		keys = append(keys, prefix+rel)
+	}
+
+	if err := deleteStorageObjects(ctx, storageClient, data.Bucket.ValueString(), keys); err != nil {
+		resp.Diagnostics.AddError("Storage API Error", fmt.Sprintf("Unable to delete storage objects: %s", err))
+		return
+	}
+}
+
+type localStorageFile struct {
+	absPath string
+	md5     string
+}
+
+// localFiles walks the source directory and hashes every file that matches
+// the include and exclude globs, keyed by slash separated relative path.
+func (r *StorageDirectorySyncResource) localFiles(ctx context.Context, data *StorageDirectorySyncResourceModel) (map[string]localStorageFile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	include, d := compileGlobs(ctx, path.Root("include"), data.Include)
+	diags.Append(d...)
+	exclude, d := compileGlobs(ctx, path.Root("exclude"), data.Exclude)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	root := data.SourceDir.ValueString()
+	files := map[string]localStorageFile{}
+
+	err := filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if len(include) > 0 && !matchesAnyGlob(include, rel) {
+			return nil
+		}
+		if matchesAnyGlob(exclude, rel) {
+			return nil
+		}
+
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+
+		files[rel] = localStorageFile{absPath: filePath, md5: contentMD5(content)}
+		return nil
+	})
+	if err != nil {
+		diags.AddAttributeError(path.Root("source_dir"), "Unable to Read Source Directory", fmt.Sprintf("Unable to read %s: %s", root, err))
+		return nil, diags
+	}
+
+	return files, diags
+}
+
+// sync uploads changed files and, when enabled, deletes remote objects that no
+// longer exist locally. The files attribute of data is updated to reflect what
+// was applied, including partial progress when some operations fail.
+func (r *StorageDirectorySyncResource) sync(ctx context.Context, data *StorageDirectorySyncResourceModel, previous map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	bucket := data.Bucket.ValueString()
+	prefix := storageObjectPrefix(data.Prefix.ValueString())
+
+	files, d := r.localFiles(ctx, data)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	storageClient, err := newStorageClient(ctx, r.providerData, data.ProjectRef.ValueString())
+	if err != nil {
+		diags.AddError("Storage Client Error", fmt.Sprintf("Unable to create storage client: %s", err))
+		return diags
+	}
+
+	remote, err := listStorageObjectsRecursive(ctx, storageClient, bucket, prefix)
+	if err != nil {
+		diags.AddError("Storage API Error", fmt.Sprintf("Unable to list storage objects: %s", err))
+		return diags
+	}
+
+	applied := map[string]string{}
+	var mutex sync.Mutex
+	var jobs []func() error
+
+	for rel, file := range files {
+		remoteHash, exists := remote[rel]
+		if exists && remoteHash == "" {
+			remoteHash = previous[rel]
+		}
+		if exists && remoteHash == file.md5 {
+			applied[rel] = file.md5
+			continue
+		}
+
+		rel, file := rel, file
+		jobs = append(jobs, func() error {
+			content, err := os.ReadFile(file.absPath)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", rel, err)
+			}
+
+			err = storageClient.UploadObjectStream(ctx, bucket+"/"+prefix+rel, bytes.NewReader(content), storage.FileOptions{
+				ContentType:  detectContentType(rel, content),
+				CacheControl: data.CacheControl.ValueString(),
+				Overwrite:    true,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to upload %s: %w", rel, err)
+			}
+
+			mutex.Lock()
+			applied[rel] = contentMD5(content)
+			mutex.Unlock()
+			return nil
+		})
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("uploading %d of %d files to %s/%s", len(jobs), len(files), bucket, prefix))
+	if err := runParallel(ctx, int(data.Parallelism.ValueInt64()), jobs); err != nil {
+		diags.AddError("Storage API Error", fmt.Sprintf("Unable to upload storage objects: %s", err))
+
+		// Removed objects are only deleted once the directory is uploaded.
+		data.Files, d = types.MapValueFrom(ctx, types.StringType, applied)
+		diags.Append(d...)
+		return diags
+	}
+
+	if data.DeleteRemoved.ValueBool() {
+		var removed []string
+		for rel := range remote {
+			if _, ok := files[rel]; !ok {
+				removed = append(removed, prefix+rel)
+			}
+		}
+		sort.Strings(removed)
+
+		if err := deleteStorageObjects(ctx, storageClient, bucket, removed); err != nil {
+			diags.AddError("Storage API Error", fmt.Sprintf("Unable to delete removed storage objects: %s", err))
+			// Keep tracking the objects that could not be removed.
+			for _, key := range removed {
+				rel := strings.TrimPrefix(key, prefix)
+				applied[rel] = remote[rel]
+			}
+		}
+	}
+
+	data.Files, d = types.MapValueFrom(ctx, types.StringType, applied)
+	diags.Append(d...)
+
+	return diags
+}
+
+// storageObjectPrefix returns the prefix of the objects that mirror the
+// directory, which is a folder ending with a slash unless it is the bucket
+// root, so that site, site/ and /site all mirror index.html to site/index.html.
+func storageObjectPrefix(prefix string) string {
+	prefix = strings.TrimLeft(prefix, "/")
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		return prefix + "/"
+	}
+	return prefix
+}
+
+// listStorageObjectsRecursive returns every object under a prefix returned by
+// storageObjectPrefix, keyed by path relative to the prefix. Values hold the
+// MD5 taken from the ETag, or an empty string when the ETag is not an MD5.
+func listStorageObjectsRecursive(ctx context.Context, storageClient *storage.StorageAPI, bucket string, prefix string) (map[string]string, error) {
+	objects := map[string]string{}
+	dirs := []string{prefix}
+
+	for len(dirs) > 0 {
+		dir := dirs[0]
+		dirs = dirs[1:]
+
+		for page := 0; ; page++ {
+			// Directories end with a slash, which lists them instead of
+			// searching them.
+			entries, err := storageClient.ListObjects(ctx, bucket, dir, page)
+			if err != nil {
+				if strings.Contains(err.Error(), "404") {
+					return objects, nil
+				}
+				return nil, err
+			}
+
+			for _, entry := range entries {
+				key := dir + entry.Name
+				if entry.Id == nil {
+					// Folders are returned without an id
+					dirs = append(dirs, key+"/")
+					continue
+				}
+
+				hash := ""
+				if entry.Metadata != nil {
+					if etag := strings.Trim(entry.Metadata.ETag, `"`); md5Pattern.MatchString(etag) {
+						hash = etag
+					}
+				}
+				objects[strings.TrimPrefix(key, prefix)] = hash
+			}
+
+			if len(entries) < storage.PAGE_LIMIT {
+				break
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+// deleteStorageObjects removes objects in batches of the storage page size.
+func deleteStorageObjects(ctx context.Context, storageClient *storage.StorageAPI, bucket string, keys []string) error {
+	for start := 0; start < len(keys); start += storage.PAGE_LIMIT {
+		end := min(start+storage.PAGE_LIMIT, len(keys))
+		if _, err := storageClient.DeleteObjects(ctx, bucket, keys[start:end]); err != nil && !strings.Contains(err.Error(), "404") {
+			return err
+		}
+	}
+	return nil
+}
+
+// runParallel runs jobs with at most parallelism of them in flight, and
+// returns all errors joined together. No more jobs are started once ctx is
+// done or a job has failed, but the jobs in flight run to completion.
+func runParallel(ctx context.Context, parallelism int, jobs []func() error) error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var errs []error
+	sem := make(chan struct{}, parallelism)
+
+	scheduling, stop := context.WithCancel(ctx)
+	defer stop()
+
+schedule:
+	for _, job := range jobs {
+		select {
+		case sem <- struct{}{}:
+		case <-scheduling.Done():
+			break schedule
+		}
+		// Both cases may have been ready
+		if scheduling.Err() != nil {
+			<-sem
+			break schedule
+		}
+
+		wg.Add(1)
+		go func(job func() error) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := job(); err != nil {
+				mutex.Lock()
+				errs = append(errs, err)
+				mutex.Unlock()
+				stop()
+			}
+		}(job)
+	}
+
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func compileGlobs(ctx context.Context, attrPath path.Path, list types.List) ([]*regexp.Regexp, diag.Diagnostics) {
+	var globs []string
+	if list.IsNull() {
+		return nil, nil
+	}
+
+	diags := list.ElementsAs(ctx, &globs, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	patterns := make([]*regexp.Regexp, 0, len(globs))
+	for _, glob := range globs {
+		pattern, err := globToRegexp(glob)
+		if err != nil {
+			diags.AddAttributeError(attrPath, "Invalid Glob", fmt.Sprintf("Unable to parse glob %q: %s", glob, err))
+			continue
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns, diags
+}
+
+func matchesAnyGlob(patterns []*regexp.Regexp, rel string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// globToRegexp converts a glob into an anchored regular expression. `*` and
+// `?` do not cross path separators, while `**` does, and `**/` also matches
+// zero directories.
+func globToRegexp(glob string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				if i+2 < len(glob) && glob[i+2] == '/' {
+					b.WriteString("(?:.*/)?")
+					i += 2
+				} else {
+					b.WriteString(".*")
+					i++
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,300 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
+	"github.com/supabase/cli/pkg/fetcher"
+	"github.com/supabase/cli/pkg/storage"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccStorageDirectorySyncResource(t *testing.T) {
+	defer gock.OffAll()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte("<html></html>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Mock API keys endpoint for token exchange
+	mockApiKeysForTokenExchange()
+
+	gock.New("https://mayuaycdtijbctgqbycg.supabase.co").
+		Post("/storage/v1/object/list/static").
+		Reply(http.StatusOK).
+		JSON([]map[string]interface{}{})
+
+	gock.New("https://mayuaycdtijbctgqbycg.supabase.co").
+		Post("/storage/v1/object/static/site/index.html").
+		MatchHeader("Content-Type", "text/html; charset=utf-8").
+		Reply(http.StatusOK).
+		JSON(map[string]string{"Key": "static/site/index.html"})
+
+	gock.New("https://mayuaycdtijbctgqbycg.supabase.co").
+		Post("/storage/v1/object/list/static").
+		Persist().
+		Reply(http.StatusOK).
+		JSON([]map[string]interface{}{
+			{
+				"name":     "index.html",
+				"id":       "object-123",
+				"metadata": map[string]interface{}{"eTag": `"` + contentMD5([]byte("<html></html>")) + `"`},
+			},
+		})
+
+	gock.New("https://mayuaycdtijbctgqbycg.supabase.co").
+		Delete("/storage/v1/object/static").
+		Reply(http.StatusOK).
+		JSON([]map[string]interface{}{{"name": "site/index.html"}})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fmt.Sprintf(`
+resource "supabase_storage_directory_sync" "test" {
+  project_ref = "mayuaycdtijbctgqbycg"
+  bucket      = "static"
+  prefix      = "site"
+  source_dir  = %q
+}
+`, dir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("supabase_storage_directory_sync.test", "id", "static/site/"),
+					resource.TestCheckResourceAttr("supabase_storage_directory_sync.test", "files.%", "1"),
+					resource.TestCheckResourceAttr("supabase_storage_directory_sync.test", "files.index.html", contentMD5([]byte("<html></html>"))),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestStorageDirectorySyncSkipsDeleteAfterFailedUpload(t *testing.T) {
+	defer gock.OffAll()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte("<html></html>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	gock.New("https://mayuaycdtijbctgqbycg.supabase.co").
+		Post("/storage/v1/object/list/static").
+		MatchType("json").
+		JSON(storage.ListObjectsQuery{Prefix: "site/", Limit: storage.PAGE_LIMIT}).
+		Reply(http.StatusOK).
+		JSON([]map[string]interface{}{
+			{"name": "old.html", "id": "object-1", "metadata": map[string]interface{}{"eTag": `"aeea24456831aaac6c5c189afd3532a5"`}},
+		})
+
+	gock.New("https://mayuaycdtijbctgqbycg.supabase.co").
+		Post("/storage/v1/object/static/site/index.html").
+		Reply(http.StatusInternalServerError).
+		JSON(map[string]string{"message": "internal error"})
+
+	// Deleting old.html would fail the test, since it is not mocked
+	r := &StorageDirectorySyncResource{providerData: &settings.SupabaseProviderData{ServiceRoleKey: "service-role-key"}}
+	data := StorageDirectorySyncResourceModel{
+		ProjectRef:    types.StringValue("mayuaycdtijbctgqbycg"),
+		Bucket:        types.StringValue("static"),
+		Prefix:        types.StringValue("/site"),
+		SourceDir:     types.StringValue(dir),
+		Include:       types.ListNull(types.StringType),
+		Exclude:       types.ListNull(types.StringType),
+		DeleteRemoved: types.BoolValue(true),
+		Parallelism:   types.Int64Value(4),
+		CacheControl:  types.StringValue("max-age=3600"),
+	}
+
+	diags := r.sync(context.Background(), &data, map[string]string{})
+	if !diags.HasError() {
+		t.Fatalf("Expected the upload to fail")
+	}
+	if len(diags) != 1 {
+		t.Errorf("Expected only the upload error, got %v", diags)
+	}
+	if len(data.Files.Elements()) != 0 {
+		t.Errorf("Expected no applied files, got %v", data.Files)
+	}
+	if gock.HasUnmatchedRequest() {
+		t.Errorf("Unexpected requests %v", gock.GetUnmatchedRequests())
+	}
+}
+
+func TestListStorageObjectsRecursive(t *testing.T) {
+	// Every prefix lists the site folder once normalized
+	for _, prefix := range []string{"site/", "site", "/site"} {
+		t.Run(prefix, func(t *testing.T) {
+			defer gock.OffAll()
+
+			gock.New("https://mayuaycdtijbctgqbycg.supabase.co").
+				Post("/storage/v1/object/list/static").
+				MatchType("json").
+				JSON(storage.ListObjectsQuery{Prefix: "site/", Limit: storage.PAGE_LIMIT}).
+				Reply(http.StatusOK).
+				JSON([]map[string]interface{}{
+					{"name": "css", "id": nil},
+					{"name": "index.html", "id": "object-1", "metadata": map[string]interface{}{"eTag": `"aeea24456831aaac6c5c189afd3532a5"`}},
+				})
+
+			gock.New("https://mayuaycdtijbctgqbycg.supabase.co").
+				Post("/storage/v1/object/list/static").
+				MatchType("json").
+				JSON(storage.ListObjectsQuery{Prefix: "site/css/", Limit: storage.PAGE_LIMIT}).
+				Reply(http.StatusOK).
+				JSON([]map[string]interface{}{
+					{"name": "app.css", "id": "object-2", "metadata": map[string]interface{}{"eTag": `"abc-2"`}},
+				})
+
+			client := &storage.StorageAPI{Fetcher: fetcher.NewFetcher("https://mayuaycdtijbctgqbycg.supabase.co")}
+
+			objects, err := listStorageObjectsRecursive(context.Background(), client, "static", storageObjectPrefix(prefix))
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if len(objects) != 2 {
+				t.Fatalf("Expected 2 objects, got %v", objects)
+			}
+			if objects["index.html"] != "aeea24456831aaac6c5c189afd3532a5" {
+				t.Errorf("Unexpected hash for index.html: %q", objects["index.html"])
+			}
+			// Multipart ETags are not an MD5
+			if hash, ok := objects["css/app.css"]; !ok || hash != "" {
+				t.Errorf("Expected css/app.css without hash, got %q", hash)
+			}
+		})
+	}
+}
+
+func TestStorageObjectPrefix(t *testing.T) {
+	cases := map[string]string{
+		"":          "",
+		"site":      "site/",
+		"site/":     "site/",
+		"site/docs": "site/docs/",
+		"/site":     "site/",
+		"/":         "",
+	}
+
+	for prefix, expected := range cases {
+		if actual := storageObjectPrefix(prefix); actual != expected {
+			t.Errorf("Expected prefix %q to be %q, got %q", prefix, expected, actual)
+		}
+	}
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	cases := []struct {
+		glob  string
+		path  string
+		match bool
+	}{
+		{"*.html", "index.html", true},
+		{"*.html", "docs/index.html", false},
+		{"**/*.map", "app.js.map", true},
+		{"**/*.map", "js/vendor/app.js.map", true},
+		{"assets/**", "assets/img/logo.png", true},
+		{"assets/**", "public/assets/logo.png", false},
+		{"file?.txt", "file1.txt", true},
+		{"file?.txt", "file/.txt", false},
+		{"a+b.txt", "a+b.txt", true},
+	}
+
+	for _, c := range cases {
+		pattern, err := globToRegexp(c.glob)
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %s", c.glob, err)
+		}
+		if actual := pattern.MatchString(c.path); actual != c.match {
+			t.Errorf("Expected %q matching %q to be %t", c.glob, c.path, c.match)
+		}
+	}
+}
+
+func TestRunParallel(t *testing.T) {
+	var running, peak atomic.Int32
+	jobs := make([]func() error, 20)
+	for i := range jobs {
+		jobs[i] = func() error {
+			current := running.Add(1)
+			defer running.Add(-1)
+			for {
+				previous := peak.Load()
+				if current <= previous || peak.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+			if i == 3 {
+				return errors.New("failed")
+			}
+			return nil
+		}
+	}
+
+	err := runParallel(context.Background(), 4, jobs)
+	if err == nil || err.Error() != "failed" {
+		t.Errorf("Expected joined error, got %v", err)
+	}
+	if peak.Load() > 4 {
+		t.Errorf("Expected at most 4 concurrent jobs, got %d", peak.Load())
+	}
+}
+
+func TestRunParallelStopsScheduling(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		var started atomic.Int32
+		jobs := make([]func() error, 20)
+		for i := range jobs {
+			jobs[i] = func() error {
+				started.Add(1)
+				if i == 0 {
+					return errors.New("failed")
+				}
+				return nil
+			}
+		}
+
+		err := runParallel(context.Background(), 1, jobs)
+		if err == nil || err.Error() != "failed" {
+			t.Errorf("Expected the job error, got %v", err)
+		}
+		// The failed job gives up its slot only after stopping the scheduling
+		if started.Load() != 1 {
+			t.Errorf("Expected no jobs to start after the failure, got %d", started.Load())
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var started atomic.Int32
+		jobs := make([]func() error, 20)
+		for i := range jobs {
+			jobs[i] = func() error {
+				started.Add(1)
+				return nil
+			}
+		}
+
+		if err := runParallel(ctx, 4, jobs); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if started.Load() != 0 {
+			t.Errorf("Expected no jobs to start, got %d", started.Load())
+		}
+	})
+}
@@ -0,0 +1,509 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	frameworkpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
+	"github.com/supabase/cli/pkg/storage"
+)
+
+var (
+	_ resource.Resource                     = &StorageObjectResource{}
+	_ resource.ResourceWithConfigure        = &StorageObjectResource{}
+	_ resource.ResourceWithConfigValidators = &StorageObjectResource{}
+	_ resource.ResourceWithModifyPlan       = &StorageObjectResource{}
+	_ resource.ResourceWithImportState      = &StorageObjectResource{}
+)
+
+// md5Pattern matches ETags of single part uploads, which are the MD5 of the
+// object content.
+var md5Pattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+func NewStorageObjectResource() resource.Resource {
+	return &StorageObjectResource{}
+}
+
+type StorageObjectResource struct {
+	providerData *settings.SupabaseProviderData
+}
+
+type StorageObjectResourceModel struct {
+	ProjectRef   types.String `tfsdk:"project_ref"`
+	Id           types.String `tfsdk:"id"`
+	Bucket       types.String `tfsdk:"bucket"`
+	Path         types.String `tfsdk:"path"`
+	Source       types.String `tfsdk:"source"`
+	Content      types.String `tfsdk:"content"`
+	ContentType  types.String `tfsdk:"content_type"`
+	CacheControl types.String `tfsdk:"cache_control"`
+	Upsert       types.Bool   `tfsdk:"upsert"`
+	ContentMd5   types.String `tfsdk:"content_md5"`
+	Etag         types.String `tfsdk:"etag"`
+	Size         types.Int64  `tfsdk:"size"`
+	UpdatedAt    types.String `tfsdk:"updated_at"`
+}
+
+func (r *StorageObjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_storage_object"
+}
+
+func (r *StorageObjectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+
+Manages an object in a Supabase storage bucket.
+
+The object is uploaded again whenever the MD5 of the local content differs from the ETag of the remote object, including when the remote object was changed outside of Terraform.
+
+Refer to the [Supabase Storage documentation](https://supabase.com/docs/guides/storage) for more information.
+
+## Example Usage
+
+~~~hcl
+resource "supabase_storage_object" "avatar" {
+  project_ref   = "abcdefghijklmnopqrst"
+  bucket        = supabase_storage_bucket.avatars.name
+  path          = "defaults/avatar.png"
+  source        = "${path.module}/assets/avatar.png"
+  cache_control = "max-age=86400"
+}
+~~~
+`,
+		Attributes: map[string]schema.Attribute{
+			"project_ref": schema.StringAttribute{
+				MarkdownDescription: "Project reference ID. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Object identifier in the format `bucket/path`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "Name of the bucket to upload into",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path of the object within the bucket",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Path to a local file to upload. Conflicts with `content`.",
+				Optional:            true,
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Inline content to upload. Conflicts with `source`.",
+				Optional:            true,
+			},
+			"content_type": schema.StringAttribute{
+				MarkdownDescription: "MIME type of the object. Detected from the path extension or the content when omitted.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cache_control": schema.StringAttribute{
+				MarkdownDescription: "Cache-Control header served with the object. Defaults to `max-age=3600`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("max-age=3600"),
+			},
+			"upsert": schema.BoolAttribute{
+				MarkdownDescription: "Whether to overwrite an existing object at the same path on create. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"content_md5": schema.StringAttribute{
+				MarkdownDescription: "Hex encoded MD5 of the content",
+				Computed:            true,
+			},
+			"etag": schema.StringAttribute{
+				MarkdownDescription: "ETag of the remote object",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "Size of the remote object in bytes",
+				Computed:            true,
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "When the object was last updated",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *StorageObjectResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			frameworkpath.MatchRoot("source"),
+			frameworkpath.MatchRoot("content"),
+		),
+	}
+}
+
+func (r *StorageObjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*settings.SupabaseProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *settings.SupabaseProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// ModifyPlan falls back to the provider project_ref, and hashes the local
+// content so that changes to the source file, or drift of the remote object
+// detected during refresh, produce a diff.
+func (r *StorageObjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	r.providerData.PlanProjectRef(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan StorageObjectResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Source.IsUnknown() || plan.Content.IsUnknown() {
+		plan.ContentMd5 = types.StringUnknown()
+	} else {
+		content, diags := storageObjectContent(&plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.ContentMd5 = types.StringValue(contentMD5(content))
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state StorageObjectResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !plan.ContentMd5.Equal(state.ContentMd5) || !plan.CacheControl.Equal(state.CacheControl) ||
+			(!plan.ContentType.IsUnknown() && !plan.ContentType.Equal(state.ContentType)) {
+			plan.Etag = types.StringUnknown()
+			plan.Size = types.Int64Unknown()
+			plan.UpdatedAt = types.StringUnknown()
+		} else {
+			plan.Size = state.Size
+			plan.UpdatedAt = state.UpdatedAt
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *StorageObjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data StorageObjectResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	storageClient, err := newStorageClient(ctx, r.providerData, data.ProjectRef.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Storage Client Error", fmt.Sprintf("Unable to create storage client: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(r.upload(ctx, storageClient, &data, data.Upsert.ValueBool())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created storage object")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StorageObjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data StorageObjectResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	storageClient, err := newStorageClient(ctx, r.providerData, data.ProjectRef.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Storage Client Error", fmt.Sprintf("Unable to create storage client: %s", err))
+		return
+	}
+
+	object, err := getStorageObject(ctx, storageClient, data.Bucket.ValueString(), data.Path.ValueString())
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			// Object or bucket no longer exists
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Storage API Error", fmt.Sprintf("Unable to read storage object: %s", err))
+		return
+	}
+
+	updateDataFromStorageObject(&data, object)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StorageObjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data StorageObjectResourceModel
+	var state StorageObjectResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only upload again when something that is stored with the object
+	// changed; switching between source and content with identical bytes is
+	// a no-op.
+	if data.ContentMd5.Equal(state.ContentMd5) && data.CacheControl.Equal(state.CacheControl) && data.ContentType.Equal(state.ContentType) {
+		data.Etag = state.Etag
+		data.Size = state.Size
+		data.UpdatedAt = state.UpdatedAt
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	storageClient, err := newStorageClient(ctx, r.providerData, data.ProjectRef.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Storage Client Error", fmt.Sprintf("Unable to create storage client: %s", err))
+		return
+	}
+
+	// The resource owns the object, so updates always overwrite it.
+	resp.Diagnostics.Append(r.upload(ctx, storageClient, &data, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StorageObjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data StorageObjectResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	storageClient, err := newStorageClient(ctx, r.providerData, data.ProjectRef.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Storage Client Error", fmt.Sprintf("Unable to create storage client: %s", err))
+		return
+	}
+
+	_, err = storageClient.DeleteObjects(ctx, data.Bucket.ValueString(), []string{data.Path.ValueString()})
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		resp.Diagnostics.AddError("Storage API Error", fmt.Sprintf("Unable to delete storage object: %s", err))
+		return
+	}
+}
+
+func (r *StorageObjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.SplitN(req.ID, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the format project_ref/bucket/path, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, frameworkpath.Root("project_ref"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, frameworkpath.Root("bucket"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, frameworkpath.Root("path"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, frameworkpath.Root("id"), parts[1]+"/"+parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, frameworkpath.Root("upsert"), false)...)
+}
+
+func (r *StorageObjectResource) upload(ctx context.Context, storageClient *storage.StorageAPI, data *StorageObjectResourceModel, overwrite bool) diag.Diagnostics {
+	content, diags := storageObjectContent(data)
+	if diags.HasError() {
+		return diags
+	}
+
+	if data.ContentType.IsUnknown() || data.ContentType.IsNull() {
+		data.ContentType = types.StringValue(detectContentType(data.Path.ValueString(), content))
+	}
+
+	remotePath := data.Bucket.ValueString() + "/" + strings.TrimPrefix(data.Path.ValueString(), "/")
+	err := storageClient.UploadObjectStream(ctx, remotePath, bytes.NewReader(content), storage.FileOptions{
+		ContentType:  data.ContentType.ValueString(),
+		CacheControl: data.CacheControl.ValueString(),
+		Overwrite:    overwrite,
+	})
+	if err != nil {
+		msg := fmt.Sprintf("Unable to upload storage object, got error: %s", err)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Storage API Error", msg)}
+	}
+
+	// Read back the uploaded object to get all computed fields
+	object, err := getStorageObject(ctx, storageClient, data.Bucket.ValueString(), data.Path.ValueString())
+	if err != nil {
+		msg := fmt.Sprintf("Unable to read uploaded storage object, got error: %s", err)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Storage API Error", msg)}
+	}
+
+	// Keep the planned values for attributes the server may normalise, so
+	// that the applied state matches the plan.
+	contentType, cacheControl := data.ContentType, data.CacheControl
+	updateDataFromStorageObject(data, object)
+	data.ContentType, data.CacheControl = contentType, cacheControl
+	data.ContentMd5 = types.StringValue(contentMD5(content))
+
+	return nil
+}
+
+// storageObjectContent returns the bytes to upload, read either from the
+// source file or from the inline content.
+func storageObjectContent(data *StorageObjectResourceModel) ([]byte, diag.Diagnostics) {
+	if !data.Content.IsNull() {
+		return []byte(data.Content.ValueString()), nil
+	}
+
+	if data.Source.IsNull() {
+		return nil, diag.Diagnostics{diag.NewAttributeErrorDiagnostic(
+			frameworkpath.Root("source"),
+			"Missing Object Content",
+			"Either source or content must be set.",
+		)}
+	}
+
+	content, err := os.ReadFile(data.Source.ValueString())
+	if err != nil {
+		return nil, diag.Diagnostics{diag.NewAttributeErrorDiagnostic(
+			frameworkpath.Root("source"),
+			"Unable to Read Source File",
+			fmt.Sprintf("Unable to read %s: %s", data.Source.ValueString(), err),
+		)}
+	}
+
+	return content, nil
+}
+
+// getStorageObject looks up a single object by listing its parent directory,
+// since the Storage API has no endpoint that returns object metadata alone.
+func getStorageObject(ctx context.Context, storageClient *storage.StorageAPI, bucket string, objectPath string) (*storage.ObjectResponse, error) {
+	objectPath = strings.TrimPrefix(objectPath, "/")
+	name := path.Base(objectPath)
+
+	for page := 0; ; page++ {
+		objects, err := storageClient.ListObjects(ctx, bucket, objectPath, page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		for _, object := range objects {
+			// Folders are returned without an id
+			if object.Name == name && object.Id != nil {
+				return &object, nil
+			}
+		}
+
+		if len(objects) < storage.PAGE_LIMIT {
+			break
+		}
+	}
+
+	return nil, fmt.Errorf("object not found: 404")
+}
+
+func updateDataFromStorageObject(data *StorageObjectResourceModel, object *storage.ObjectResponse) {
+	data.Id = types.StringValue(data.Bucket.ValueString() + "/" + strings.TrimPrefix(data.Path.ValueString(), "/"))
+	data.UpdatedAt = types.StringPointerValue(object.UpdatedAt)
+
+	if object.Metadata == nil {
+		data.Etag = types.StringNull()
+		data.Size = types.Int64Null()
+		return
+	}
+
+	etag := strings.Trim(object.Metadata.ETag, `"`)
+	data.Etag = types.StringValue(etag)
+	data.Size = types.Int64Value(int64(object.Metadata.Size))
+	if object.Metadata.Mimetype != "" {
+		data.ContentType = types.StringValue(object.Metadata.Mimetype)
+	}
+	if object.Metadata.CacheControl != "" {
+		data.CacheControl = types.StringValue(object.Metadata.CacheControl)
+	}
+
+	// Single part uploads use the content MD5 as ETag, which lets refresh
+	// detect objects that were replaced outside of Terraform.
+	if md5Pattern.MatchString(etag) {
+		data.ContentMd5 = types.StringValue(etag)
+	}
+}
+
+func contentMD5(content []byte) string {
+	sum := md5.Sum(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func detectContentType(objectPath string, content []byte) string {
+	if contentType := mime.TypeByExtension(path.Ext(objectPath)); contentType != "" {
+		return contentType
+	}
+	return http.DetectContentType(content)
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/supabase/cli/pkg/fetcher"
+	"github.com/supabase/cli/pkg/storage"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccStorageObjectResource(t *testing.T) {
+	defer gock.OffAll()
+
+	// Mock API keys endpoint for token exchange
+	mockApiKeysForTokenExchange()
+
+	gock.New("https://mayuaycdtijbctgqbycg.supabase.co").
+		Post("/storage/v1/object/assets/config/app.json").
+		MatchHeader("Content-Type", "application/json").
+		MatchHeader("Cache-Control", "max-age=3600").
+		Reply(http.StatusOK).
+		JSON(map[string]string{"Key": "assets/config/app.json"})
+
+	// MD5 of `{"theme":"dark"}`
+	gock.New("https://mayuaycdtijbctgqbycg.supabase.co").
+		Post("/storage/v1/object/list/assets").
+		Times(4).
+		Reply(http.StatusOK).
+		JSON([]map[string]interface{}{
+			{
+				"name":       "app.json",
+				"id":         "object-123",
+				"updated_at": "2024-01-01T00:00:00Z",
+				"metadata": map[string]interface{}{
+					"eTag":         `"aeea24456831aaac6c5c189afd3532a5"`,
+					"size":         16,
+					"mimetype":     "application/json",
+					"cacheControl": "max-age=3600",
+				},
+			},
+		})
+
+	gock.New("https://mayuaycdtijbctgqbycg.supabase.co").
+		Delete("/storage/v1/object/assets").
+		Reply(http.StatusOK).
+		JSON([]map[string]interface{}{{"name": "config/app.json"}})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "supabase_storage_object" "test" {
+  project_ref = "mayuaycdtijbctgqbycg"
+  bucket      = "assets"
+  path        = "config/app.json"
+  content     = jsonencode({ theme = "dark" })
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("supabase_storage_object.test", "id", "assets/config/app.json"),
+					resource.TestCheckResourceAttr("supabase_storage_object.test", "content_type", "application/json"),
+					resource.TestCheckResourceAttr("supabase_storage_object.test", "size", "16"),
+					resource.TestCheckResourceAttr("supabase_storage_object.test", "etag", "aeea24456831aaac6c5c189afd3532a5"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestGetStorageObject(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New("https://mayuaycdtijbctgqbycg.supabase.co").
+		Post("/storage/v1/object/list/assets").
+		MatchType("json").
+		JSON(storage.ListObjectsQuery{Prefix: "config/", Search: "app.json", Limit: storage.PAGE_LIMIT}).
+		Reply(http.StatusOK).
+		JSON([]map[string]interface{}{
+			// Folders share the name prefix but have no id
+			{"name": "app.json", "id": nil},
+			{"name": "app.json.bak", "id": "object-456"},
+			{"name": "app.json", "id": "object-123", "metadata": map[string]interface{}{"eTag": `"abc"`}},
+		})
+
+	client := &storage.StorageAPI{Fetcher: fetcher.NewFetcher("https://mayuaycdtijbctgqbycg.supabase.co")}
+
+	object, err := getStorageObject(context.Background(), client, "assets", "/config/app.json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if object.Id == nil || *object.Id != "object-123" {
+		t.Errorf("Expected object-123, got %v", object.Id)
+	}
+}
+
+func TestStorageObjectContentHelpers(t *testing.T) {
+	if actual := contentMD5([]byte(`{"theme":"dark"}`)); actual != "aeea24456831aaac6c5c189afd3532a5" {
+		t.Errorf("Unexpected MD5 %s", actual)
+	}
+
+	if actual := detectContentType("images/logo.png", nil); actual != "image/png" {
+		t.Errorf("Expected image/png from extension, got %s", actual)
+	}
+
+	if actual := detectContentType("README", []byte("plain text")); actual != "text/plain; charset=utf-8" {
+		t.Errorf("Expected sniffed text/plain, got %s", actual)
+	}
+}
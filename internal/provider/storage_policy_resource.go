@@ -0,0 +1,497 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
+)
+
+var (
+	_ resource.Resource                = &StoragePolicyResource{}
+	_ resource.ResourceWithConfigure   = &StoragePolicyResource{}
+	_ resource.ResourceWithImportState = &StoragePolicyResource{}
+	_ resource.ResourceWithModifyPlan  = &StoragePolicyResource{}
+)
+
+func NewStoragePolicyResource() resource.Resource {
+	return &StoragePolicyResource{}
+}
+
+// StoragePolicyResource manages a row level security policy on
+// storage.objects that is scoped to a single bucket.
+type StoragePolicyResource struct {
+	providerData *settings.SupabaseProviderData
+}
+
+type StoragePolicyResourceModel struct {
+	ProjectRef types.String `tfsdk:"project_ref"`
+	Id         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Bucket     types.String `tfsdk:"bucket"`
+	Operation  types.String `tfsdk:"operation"`
+	Roles      types.Set    `tfsdk:"roles"`
+	Condition  types.String `tfsdk:"condition"`
+}
+
+// storagePolicyRow is a row of pg_policies for storage.objects.
+type storagePolicyRow struct {
+	Name      string          `json:"policyname"`
+	Command   string          `json:"cmd"`
+	Roles     json.RawMessage `json:"roles"`
+	Using     *string         `json:"qual"`
+	WithCheck *string         `json:"with_check"`
+}
+
+// storagePolicyExpressionKey is the private state key holding the expression
+// of the policy as Postgres stored it when it was last applied or read.
+const storagePolicyExpressionKey = "expression"
+
+// storagePolicyGuardPattern extracts the bucket guard and the user condition
+// from an expression as normalized by Postgres.
+var storagePolicyGuardPattern = regexp.MustCompile(`^\(*bucket_id = '((?:[^']|'')*)'::text\)(?: AND (.*)\))?$`)
+
+func (r *StoragePolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_storage_policy"
+}
+
+func (r *StoragePolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+
+Manages a row level security policy on ` + "`storage.objects`" + ` that is scoped to a single bucket.
+
+The ` + "`bucket_id = '<bucket>'`" + ` guard is added to the policy automatically, so ` + "`condition`" + ` only needs to express the access rule itself. The condition is used as the ` + "`USING`" + ` expression for select and delete, as the ` + "`WITH CHECK`" + ` expression for insert, and as both for update.
+
+See the [storage access control docs](https://supabase.com/docs/guides/storage/security/access-control) for examples of policy conditions.
+
+## Example Usage
+
+~~~hcl
+resource "supabase_storage_bucket" "avatars" {
+  project_ref = "abcdefghijklmnopqrst"
+  name        = "avatars"
+}
+
+resource "supabase_storage_policy" "avatars_upload" {
+  project_ref = "abcdefghijklmnopqrst"
+  name        = "Users upload their own avatar"
+  bucket      = supabase_storage_bucket.avatars.name
+  operation   = "insert"
+  roles       = ["authenticated"]
+  condition   = "(storage.foldername(name))[1] = (select auth.uid()::text)"
+}
+~~~
+`,
+		Attributes: map[string]schema.Attribute{
+			"project_ref": schema.StringAttribute{
+				MarkdownDescription: "Project reference ID. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Policy identifier, same as name",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the policy, unique among the policies on `storage.objects`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 63),
+				},
+			},
+			"bucket": schema.StringAttribute{
+				MarkdownDescription: "Name of the bucket the policy applies to",
+				Required:            true,
+			},
+			"operation": schema.StringAttribute{
+				MarkdownDescription: "Operation the policy applies to. One of `select`, `insert`, `update` or `delete`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("select", "insert", "update", "delete"),
+				},
+			},
+			"roles": schema.SetAttribute{
+				MarkdownDescription: "Roles the policy applies to. Defaults to `[\"public\"]`, which includes every role.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{types.StringValue("public")})),
+			},
+			"condition": schema.StringAttribute{
+				MarkdownDescription: "SQL condition that rows must satisfy in addition to belonging to the bucket. When omitted, the policy grants access to every object in the bucket.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *StoragePolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*settings.SupabaseProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *settings.SupabaseProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// ModifyPlan falls back to the provider project_ref when the resource omits it.
+func (r *StoragePolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.PlanProjectRef(ctx, req, resp)
+}
+
+func (r *StoragePolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data StoragePolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, diags := storagePolicyStatement(ctx, "CREATE", &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(runDatabaseQuery(ctx, r.providerData, data.ProjectRef.ValueString(), query, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = data.Name
+
+	row, diags := readStoragePolicy(ctx, r.providerData, data.ProjectRef.ValueString(), data.Id.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if row != nil {
+		resp.Diagnostics.Append(setStoragePolicyExpression(ctx, resp.Private, row)...)
+	}
+
+	tflog.Trace(ctx, "created storage policy")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StoragePolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data StoragePolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	row, diags := readStoragePolicy(ctx, r.providerData, data.ProjectRef.ValueString(), data.Id.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if row == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	raw, diags := req.Private.GetKey(ctx, storagePolicyExpressionKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var applied *string
+	if raw != nil {
+		if err := json.Unmarshal(raw, &applied); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to decode private state: %s", err))
+			return
+		}
+	} else if !data.Bucket.IsNull() {
+		// State written before the expression was recorded is trusted once
+		applied = row.expression()
+	}
+
+	resp.Diagnostics.Append(updateDataFromStoragePolicy(ctx, &data, row, applied)...)
+	resp.Diagnostics.Append(setStoragePolicyExpression(ctx, resp.Private, row)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StoragePolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data StoragePolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, diags := storagePolicyStatement(ctx, "ALTER", &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(runDatabaseQuery(ctx, r.providerData, data.ProjectRef.ValueString(), query, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = data.Name
+
+	row, diags := readStoragePolicy(ctx, r.providerData, data.ProjectRef.ValueString(), data.Id.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if row != nil {
+		resp.Diagnostics.Append(setStoragePolicyExpression(ctx, resp.Private, row)...)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StoragePolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data StoragePolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := fmt.Sprintf("DROP POLICY IF EXISTS %s ON storage.objects", quoteIdentifier(data.Id.ValueString()))
+	resp.Diagnostics.Append(runDatabaseQuery(ctx, r.providerData, data.ProjectRef.ValueString(), query, nil)...)
+}
+
+func (r *StoragePolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.SplitN(req.ID, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the format project_ref/policy_name, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_ref"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+// storagePolicyStatement builds a CREATE or ALTER POLICY statement with the
+// bucket guard prepended to the condition.
+func storagePolicyStatement(ctx context.Context, verb string, data *StoragePolicyResourceModel) (string, diag.Diagnostics) {
+	var roles []string
+	diags := data.Roles.ElementsAs(ctx, &roles, false)
+	if diags.HasError() {
+		return "", diags
+	}
+	sort.Strings(roles)
+
+	quotedRoles := make([]string, len(roles))
+	for i, role := range roles {
+		// PUBLIC is a keyword, not a role, and must not be quoted.
+		if strings.EqualFold(role, "public") {
+			quotedRoles[i] = "public"
+		} else {
+			quotedRoles[i] = quoteIdentifier(role)
+		}
+	}
+
+	expression := "bucket_id = " + quoteLiteral(data.Bucket.ValueString())
+	if condition := strings.TrimSpace(data.Condition.ValueString()); condition != "" {
+		expression += " AND (" + condition + ")"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s POLICY %s ON storage.objects", verb, quoteIdentifier(data.Name.ValueString()))
+	if verb == "CREATE" {
+		fmt.Fprintf(&b, " FOR %s", strings.ToUpper(data.Operation.ValueString()))
+	}
+	fmt.Fprintf(&b, " TO %s", strings.Join(quotedRoles, ", "))
+
+	switch data.Operation.ValueString() {
+	case "insert":
+		fmt.Fprintf(&b, " WITH CHECK (%s)", expression)
+	case "update":
+		fmt.Fprintf(&b, " USING (%s) WITH CHECK (%s)", expression, expression)
+	default:
+		fmt.Fprintf(&b, " USING (%s)", expression)
+	}
+
+	return b.String(), diags
+}
+
+// readStoragePolicy returns the pg_policies row of a storage policy, or nil
+// when the policy does not exist.
+func readStoragePolicy(ctx context.Context, providerData *settings.SupabaseProviderData, projectRef string, name string) (*storagePolicyRow, diag.Diagnostics) {
+	query := fmt.Sprintf(
+		"SELECT policyname, cmd, roles, qual, with_check FROM pg_policies WHERE schemaname = 'storage' AND tablename = 'objects' AND policyname = %s",
+		quoteLiteral(name),
+	)
+
+	var rows []storagePolicyRow
+	diags := runDatabaseQuery(ctx, providerData, projectRef, query, &rows)
+	if diags.HasError() || len(rows) == 0 {
+		return nil, diags
+	}
+	return &rows[0], diags
+}
+
+// expression returns the expression holding the bucket guard, which is the
+// USING expression, or the WITH CHECK expression for insert.
+func (row *storagePolicyRow) expression() *string {
+	if row.Using != nil {
+		return row.Using
+	}
+	return row.WithCheck
+}
+
+// privateState is the private state of a resource response.
+type privateState interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// setStoragePolicyExpression records the stored expression of the policy in
+// private state, so that Read can tell whether it changed.
+func setStoragePolicyExpression(ctx context.Context, private privateState, row *storagePolicyRow) diag.Diagnostics {
+	raw, err := json.Marshal(row.expression())
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to encode private state: %s", err))}
+	}
+	return private.SetKey(ctx, storagePolicyExpressionKey, raw)
+}
+
+// updateDataFromStoragePolicy refreshes the model from pg_policies. Postgres
+// normalizes the expression, so the bucket and condition in data are kept
+// while the stored expression still equals applied, the one recorded when the
+// policy was last applied or read. Otherwise they are parsed from the stored
+// expression, which detects changes made outside of Terraform and recovers
+// them on import.
+func updateDataFromStoragePolicy(ctx context.Context, data *StoragePolicyResourceModel, row *storagePolicyRow, applied *string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.Name = types.StringValue(row.Name)
+	data.Operation = types.StringValue(strings.ToLower(row.Command))
+
+	roles, err := parsePostgresArray(row.Roles)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to parse policy roles: %s", err))
+		return diags
+	}
+
+	var d diag.Diagnostics
+	data.Roles, d = types.SetValueFrom(ctx, types.StringType, roles)
+	diags.Append(d...)
+
+	expression := row.expression()
+	if expression == nil {
+		return diags
+	}
+	if applied != nil && *applied == *expression && !data.Bucket.IsNull() {
+		return diags
+	}
+
+	match := storagePolicyGuardPattern.FindStringSubmatch(*expression)
+	if match == nil {
+		// The guard was removed, so the whole expression is the condition
+		data.Condition = types.StringValue(trimWrappingParentheses(*expression))
+		return diags
+	}
+
+	data.Bucket = types.StringValue(strings.ReplaceAll(match[1], "''", "'"))
+	if match[2] != "" {
+		data.Condition = types.StringValue(trimWrappingParentheses(match[2]))
+	} else {
+		data.Condition = types.StringNull()
+	}
+
+	return diags
+}
+
+// trimWrappingParentheses removes the parentheses that Postgres wraps around
+// each operand of an expression, such as `(owner = auth.uid())`, but not
+// those of `(a) OR (b)`, which do not wrap the whole expression.
+func trimWrappingParentheses(expression string) string {
+	expression = strings.TrimSpace(expression)
+	if !strings.HasPrefix(expression, "(") || !strings.HasSuffix(expression, ")") {
+		return expression
+	}
+
+	depth := 0
+	inLiteral := false
+	for i, c := range expression {
+		switch {
+		case c == '\'':
+			inLiteral = !inLiteral
+		case inLiteral:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 && i != len(expression)-1 {
+				return expression
+			}
+		}
+	}
+	return expression[1 : len(expression)-1]
+}
+
+// parsePostgresArray decodes an array column that is either returned as a
+// JSON array or in the Postgres text representation, such as `{a,b}`.
+func parsePostgresArray(raw json.RawMessage) ([]string, error) {
+	var values []string
+	if err := json.Unmarshal(raw, &values); err == nil {
+		return values, nil
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		return nil, err
+	}
+
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "{"), "}")
+	if text == "" {
+		return []string{}, nil
+	}
+
+	for _, value := range strings.Split(text, ",") {
+		values = append(values, strings.Trim(value, `"`))
+	}
+	return values, nil
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccStoragePolicyResource(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New("https://api.supabase.com").
+		Post("/v1/projects/mayuaycdtijbctgqbycg/database/query").
+		MatchType("json").
+		JSON(map[string]string{"query": `CREATE POLICY "avatars_upload" ON storage.objects FOR INSERT TO "authenticated" WITH CHECK (bucket_id = 'avatars' AND (owner = auth.uid()))`}).
+		Reply(http.StatusCreated).
+		JSON([]interface{}{})
+
+	gock.New("https://api.supabase.com").
+		Post("/v1/projects/mayuaycdtijbctgqbycg/database/query").
+		MatchType("json").
+		JSON(map[string]string{"query": `SELECT policyname, cmd, roles, qual, with_check FROM pg_policies WHERE schemaname = 'storage' AND tablename = 'objects' AND policyname = 'avatars_upload'`}).
+		Persist().
+		Reply(http.StatusCreated).
+		JSON([]map[string]interface{}{
+			{
+				"policyname": "avatars_upload",
+				"cmd":        "INSERT",
+				"roles":      "{authenticated}",
+				"qual":       nil,
+				"with_check": "((bucket_id = 'avatars'::text) AND (owner = auth.uid()))",
+			},
+		})
+
+	gock.New("https://api.supabase.com").
+		Post("/v1/projects/mayuaycdtijbctgqbycg/database/query").
+		MatchType("json").
+		JSON(map[string]string{"query": `DROP POLICY IF EXISTS "avatars_upload" ON storage.objects`}).
+		Reply(http.StatusCreated).
+		JSON([]interface{}{})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "supabase_storage_policy" "test" {
+  project_ref = "mayuaycdtijbctgqbycg"
+  name        = "avatars_upload"
+  bucket      = "avatars"
+  operation   = "insert"
+  roles       = ["authenticated"]
+  condition   = "owner = auth.uid()"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("supabase_storage_policy.test", "id", "avatars_upload"),
+					resource.TestCheckResourceAttr("supabase_storage_policy.test", "roles.#", "1"),
+					resource.TestCheckResourceAttr("supabase_storage_policy.test", "condition", "owner = auth.uid()"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "supabase_storage_policy.test",
+				ImportState:       true,
+				ImportStateId:     "mayuaycdtijbctgqbycg/avatars_upload",
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestStoragePolicyStatement(t *testing.T) {
+	data := StoragePolicyResourceModel{
+		Name:      types.StringValue(`Owner "only"`),
+		Bucket:    types.StringValue("user's"),
+		Operation: types.StringValue("update"),
+		Roles:     types.SetValueMust(types.StringType, []attr.Value{types.StringValue("public"), types.StringValue("authenticated")}),
+		Condition: types.StringValue("owner = auth.uid()"),
+	}
+
+	query, diags := storagePolicyStatement(context.Background(), "CREATE", &data)
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+
+	expected := `CREATE POLICY "Owner ""only""" ON storage.objects FOR UPDATE TO "authenticated", public USING (bucket_id = 'user''s' AND (owner = auth.uid())) WITH CHECK (bucket_id = 'user''s' AND (owner = auth.uid()))`
+	if query != expected {
+		t.Errorf("Unexpected statement:\n%s", query)
+	}
+
+	data.Operation = types.StringValue("select")
+	data.Condition = types.StringNull()
+
+	query, _ = storagePolicyStatement(context.Background(), "ALTER", &data)
+	expected = `ALTER POLICY "Owner ""only""" ON storage.objects TO "authenticated", public USING (bucket_id = 'user''s')`
+	if query != expected {
+		t.Errorf("Unexpected statement:\n%s", query)
+	}
+}
+
+func TestUpdateDataFromStoragePolicy(t *testing.T) {
+	qual := "((bucket_id = 'user''s'::text) AND (owner = auth.uid()))"
+	row := storagePolicyRow{
+		Name:    "owner_only",
+		Command: "SELECT",
+		Roles:   json.RawMessage(`["anon","authenticated"]`),
+		Using:   &qual,
+	}
+
+	// Import
+	data := StoragePolicyResourceModel{Bucket: types.StringNull(), Condition: types.StringNull()}
+	if diags := updateDataFromStoragePolicy(context.Background(), &data, &row, nil); diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+
+	if data.Bucket.ValueString() != "user's" {
+		t.Errorf("Expected bucket user's, got %s", data.Bucket)
+	}
+	if data.Condition.ValueString() != "owner = auth.uid()" {
+		t.Errorf("Unexpected condition %s", data.Condition)
+	}
+	if data.Operation.ValueString() != "select" || len(data.Roles.Elements()) != 2 {
+		t.Errorf("Unexpected operation or roles: %s %s", data.Operation, data.Roles)
+	}
+
+	// Unchanged since applied, so the configured condition is kept
+	applied := qual
+	data = StoragePolicyResourceModel{Bucket: types.StringValue("user's"), Condition: types.StringValue("owner = (select auth.uid())")}
+	if diags := updateDataFromStoragePolicy(context.Background(), &data, &row, &applied); diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+	if data.Condition.ValueString() != "owner = (select auth.uid())" {
+		t.Errorf("Expected the configured condition to be kept, got %s", data.Condition)
+	}
+
+	// Changed outside of Terraform
+	applied = "((bucket_id = 'avatars'::text) AND (owner = auth.uid()))"
+	data = StoragePolicyResourceModel{Bucket: types.StringValue("avatars"), Condition: types.StringValue("owner = auth.uid()")}
+	if diags := updateDataFromStoragePolicy(context.Background(), &data, &row, &applied); diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+	if data.Bucket.ValueString() != "user's" {
+		t.Errorf("Expected drifted bucket user's, got %s", data.Bucket)
+	}
+
+	// The condition was removed outside of Terraform
+	guard := "(bucket_id = 'avatars'::text)"
+	row.Using = &guard
+	data = StoragePolicyResourceModel{Bucket: types.StringValue("avatars"), Condition: types.StringValue("owner = auth.uid()")}
+	if diags := updateDataFromStoragePolicy(context.Background(), &data, &row, &applied); diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+	if !data.Condition.IsNull() {
+		t.Errorf("Expected no condition, got %s", data.Condition)
+	}
+}
+
+func TestTrimWrappingParentheses(t *testing.T) {
+	cases := map[string]string{
+		"(owner = auth.uid())":                        "owner = auth.uid()",
+		"((owner = auth.uid()) OR (public = true))":   "(owner = auth.uid()) OR (public = true)",
+		"(owner = auth.uid()) OR (public = true)":     "(owner = auth.uid()) OR (public = true)",
+		"((storage.foldername(name))[1] = 'a'::text)": "(storage.foldername(name))[1] = 'a'::text",
+		"(storage.foldername(name))[1] = 'a'::text":   "(storage.foldername(name))[1] = 'a'::text",
+		"(name = ')('::text)":                         "name = ')('::text",
+		"owner = auth.uid()":                          "owner = auth.uid()",
+	}
+
+	for expression, expected := range cases {
+		if actual := trimWrappingParentheses(expression); actual != expected {
+			t.Errorf("Expected %q to be trimmed to %q, got %q", expression, expected, actual)
+		}
+	}
+}
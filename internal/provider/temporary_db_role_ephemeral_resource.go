@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
+	"github.com/supabase/cli/pkg/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &TemporaryDbRoleEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &TemporaryDbRoleEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &TemporaryDbRoleEphemeralResource{}
+)
+
+// temporaryDbRolePrivateKey is the private state key holding the project of
+// the login role, which Close needs to delete it.
+const temporaryDbRolePrivateKey = "login_role"
+
+// openLoginRoles counts the login roles open in this provider process by
+// project. Deleting a login role deletes every CLI login role of the access
+// token on the project, so it waits until the last one is closed.
+var openLoginRoles = struct {
+	sync.Mutex
+	count map[string]int
+}{count: map[string]int{}}
+
+// openLoginRole records a login role opened on a project, and reports
+// whether another one was already open on it.
+func openLoginRole(projectRef string) bool {
+	openLoginRoles.Lock()
+	defer openLoginRoles.Unlock()
+
+	openLoginRoles.count[projectRef]++
+	return openLoginRoles.count[projectRef] > 1
+}
+
+// closeLoginRole records a login role closed on a project, and reports
+// whether it was the last one open on it.
+func closeLoginRole(projectRef string) bool {
+	openLoginRoles.Lock()
+	defer openLoginRoles.Unlock()
+
+	openLoginRoles.count[projectRef]--
+	if openLoginRoles.count[projectRef] > 0 {
+		return false
+	}
+	delete(openLoginRoles.count, projectRef)
+	return true
+}
+
+func NewTemporaryDbRoleEphemeralResource() ephemeral.EphemeralResource {
+	return &TemporaryDbRoleEphemeralResource{}
+}
+
+// TemporaryDbRoleEphemeralResource mints a short-lived Postgres login role
+// through the endpoint the Supabase CLI uses, and deletes it once Terraform
+// no longer needs it.
+type TemporaryDbRoleEphemeralResource struct {
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
+}
+
+type TemporaryDbRoleEphemeralResourceModel struct {
+	ProjectRef types.String `tfsdk:"project_ref"`
+	ReadOnly   types.Bool   `tfsdk:"read_only"`
+	Role       types.String `tfsdk:"role"`
+	Password   types.String `tfsdk:"password"`
+	TtlSeconds types.Int64  `tfsdk:"ttl_seconds"`
+	Host       types.String `tfsdk:"host"`
+	Port       types.Int64  `tfsdk:"port"`
+	Database   types.String `tfsdk:"database"`
+}
+
+// loginRoleBody is the request body of the CLI login-role endpoint, which
+// the generated client does not cover.
+type loginRoleBody struct {
+	ReadOnly bool `json:"read_only"`
+}
+
+// loginRole is a login role as returned by the CLI login-role endpoint.
+type loginRole struct {
+	Role       string `json:"role"`
+	Password   string `json:"password"`
+	TtlSeconds int64  `json:"ttl_seconds"`
+}
+
+// temporaryDbRolePrivate is the private state of an open login role.
+type temporaryDbRolePrivate struct {
+	ProjectRef string `json:"project_ref"`
+}
+
+func (r *TemporaryDbRoleEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_temporary_db_role"
+}
+
+func (r *TemporaryDbRoleEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+Creates a short-lived Postgres login role for the duration of a Terraform run, the same way the Supabase CLI does, and deletes it afterwards. Use it to connect to the database without a long-lived password. Requires Terraform 1.10 or later.
+
+Closing the ephemeral resource deletes every CLI login role of the access token on the project. Instances on the same project in one Terraform run share them, so the roles are deleted once the last instance is closed, but concurrent Terraform runs with the same access token revoke each other's roles.
+
+## Example Usage
+
+~~~hcl
+ephemeral "supabase_temporary_db_role" "migrations" {
+  project_ref = "mayuaycdtijbctgqbycg"
+}
+
+provider "postgresql" {
+  host     = ephemeral.supabase_temporary_db_role.migrations.host
+  port     = ephemeral.supabase_temporary_db_role.migrations.port
+  database = ephemeral.supabase_temporary_db_role.migrations.database
+  username = ephemeral.supabase_temporary_db_role.migrations.role
+  password = ephemeral.supabase_temporary_db_role.migrations.password
+}
+~~~
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"project_ref": schema.StringAttribute{
+				MarkdownDescription: "Project reference ID. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Whether the role can only read data. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "Name of the login role",
+				Computed:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password of the login role",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"ttl_seconds": schema.Int64Attribute{
+				MarkdownDescription: "Number of seconds the role can log in for",
+				Computed:            true,
+			},
+			"host": schema.StringAttribute{
+				MarkdownDescription: "Database host",
+				Computed:            true,
+			},
+			"port": schema.Int64Attribute{
+				MarkdownDescription: "Database port",
+				Computed:            true,
+			},
+			"database": schema.StringAttribute{
+				MarkdownDescription: "Database name",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *TemporaryDbRoleEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*settings.SupabaseProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *settings.SupabaseProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(providerData.RequireManagementAPI("supabase_temporary_db_role")...)
+	r.client = providerData.ManagementClient
+	r.providerData = providerData
+}
+
+func (r *TemporaryDbRoleEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	// Skipped ephemeral resources return their configuration
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
+	var data TemporaryDbRoleEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.providerData.ResolveProjectRef(&data.ProjectRef)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.ReadOnly.IsNull() {
+		data.ReadOnly = types.BoolValue(false)
+	}
+
+	var role loginRole
+	httpResp, err := managementRequest(ctx, r.client, http.MethodPost, loginRolePath(data.ProjectRef.ValueString()), loginRoleBody{ReadOnly: data.ReadOnly.ValueBool()}, &role)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create login role: %s", err))
+		return
+	}
+
+	if httpResp.StatusCode != http.StatusCreated && httpResp.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create login role, got status %d: %s", httpResp.StatusCode, httpResp.Body))
+		return
+	}
+
+	private, err := json.Marshal(temporaryDbRolePrivate{ProjectRef: data.ProjectRef.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to encode private state: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, temporaryDbRolePrivateKey, private)...)
+
+	if openLoginRole(data.ProjectRef.ValueString()) {
+		resp.Diagnostics.AddWarning(
+			"Shared Login Roles",
+			fmt.Sprintf("Another supabase_temporary_db_role is open on project %s. Closing deletes every CLI login role of the access token on the project, "+
+				"so the login roles are kept until the last instance is closed. Use a single instance per project where possible.", data.ProjectRef.ValueString()),
+		)
+	}
+
+	data.Role = types.StringValue(role.Role)
+	data.Password = types.StringValue(role.Password)
+	data.TtlSeconds = types.Int64Value(role.TtlSeconds)
+	data.Host = types.StringValue(fmt.Sprintf("db.%s.supabase.co", data.ProjectRef.ValueString()))
+	data.Port = types.Int64Value(5432)
+	data.Database = types.StringValue("postgres")
+
+	tflog.Trace(ctx, "created login role")
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *TemporaryDbRoleEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	raw, diags := req.Private.GetKey(ctx, temporaryDbRolePrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || raw == nil {
+		return
+	}
+
+	var private temporaryDbRolePrivate
+	if err := json.Unmarshal(raw, &private); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to decode private state: %s", err))
+		return
+	}
+
+	if !closeLoginRole(private.ProjectRef) {
+		tflog.Debug(ctx, "keeping login roles of other open instances", map[string]any{"project_ref": private.ProjectRef})
+		return
+	}
+
+	httpResp, err := managementRequest(ctx, r.client, http.MethodDelete, loginRolePath(private.ProjectRef), nil, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete login role: %s", err))
+		return
+	}
+
+	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusNotFound {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete login role, got status %d: %s", httpResp.StatusCode, httpResp.Body))
+		return
+	}
+
+	tflog.Trace(ctx, "deleted login role")
+}
+
+func loginRolePath(projectRef string) string {
+	return fmt.Sprintf("/v1/projects/%s/cli/login-role", projectRef)
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccTemporaryDbRoleEphemeralResource(t *testing.T) {
+	// Setup mock api
+	defer gock.OffAll()
+	defer gock.Observe(nil)
+
+	// Every opened login role must be deleted when Terraform closes it
+	var opened, deleted atomic.Int32
+	gock.Observe(func(req *http.Request, mock gock.Mock) {
+		if req.URL.Path != "/v1/projects/mayuaycdtijbctgqbycg/cli/login-role" {
+			return
+		}
+		switch req.Method {
+		case http.MethodPost:
+			opened.Add(1)
+		case http.MethodDelete:
+			deleted.Add(1)
+		}
+	})
+
+	gock.New("https://api.supabase.com").
+		Post("/v1/projects/mayuaycdtijbctgqbycg/cli/login-role").
+		JSON(map[string]interface{}{"read_only": true}).
+		Persist().
+		Reply(http.StatusCreated).
+		JSON(map[string]interface{}{
+			"role":        "cli_login_postgres",
+			"password":    "temporary-password",
+			"ttl_seconds": 900,
+		})
+	gock.New("https://api.supabase.com").
+		Delete("/v1/projects/mayuaycdtijbctgqbycg/cli/login-role").
+		Persist().
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{"message": "ok"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+ephemeral "supabase_temporary_db_role" "migrations" {
+  project_ref = "mayuaycdtijbctgqbycg"
+  read_only   = true
+}
+
+provider "echo" {
+  data = ephemeral.supabase_temporary_db_role.migrations
+}
+
+resource "echo" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.role", "cli_login_postgres"),
+					resource.TestCheckResourceAttr("echo.test", "data.password", "temporary-password"),
+					resource.TestCheckResourceAttr("echo.test", "data.ttl_seconds", "900"),
+					resource.TestCheckResourceAttr("echo.test", "data.host", "db.mayuaycdtijbctgqbycg.supabase.co"),
+					resource.TestCheckResourceAttr("echo.test", "data.port", "5432"),
+				),
+			},
+		},
+	})
+	if opened.Load() == 0 || deleted.Load() != opened.Load() {
+		t.Errorf("Expected every opened login role to be deleted, opened %d and deleted %d", opened.Load(), deleted.Load())
+	}
+}
+
+func TestOpenLoginRoles(t *testing.T) {
+	if openLoginRole("mayuaycdtijbctgqbycg") {
+		t.Errorf("Expected the first login role not to be shared")
+	}
+	if !openLoginRole("mayuaycdtijbctgqbycg") {
+		t.Errorf("Expected the second login role to be shared")
+	}
+	if openLoginRole("bbbbbbbbbbbbbbbbbbbb") {
+		t.Errorf("Expected login roles of other projects not to be shared")
+	}
+
+	// Only closing the last login role of a project deletes them
+	if closeLoginRole("mayuaycdtijbctgqbycg") {
+		t.Errorf("Expected the login roles to be kept for the open instance")
+	}
+	if !closeLoginRole("mayuaycdtijbctgqbycg") {
+		t.Errorf("Expected the last instance to delete the login roles")
+	}
+	if !closeLoginRole("bbbbbbbbbbbbbbbbbbbb") {
+		t.Errorf("Expected the only instance to delete the login roles")
+	}
+}
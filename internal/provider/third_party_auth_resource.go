@@ -0,0 +1,454 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/shellscape/terraform-provider-supabase/internal/provider/settings"
+	"github.com/supabase/cli/pkg/api"
+)
+
+var (
+	_ resource.Resource                     = &ThirdPartyAuthResource{}
+	_ resource.ResourceWithConfigure        = &ThirdPartyAuthResource{}
+	_ resource.ResourceWithConfigValidators = &ThirdPartyAuthResource{}
+	_ resource.ResourceWithImportState      = &ThirdPartyAuthResource{}
+	_ resource.ResourceWithModifyPlan       = &ThirdPartyAuthResource{}
+)
+
+func NewThirdPartyAuthResource() resource.Resource {
+	return &ThirdPartyAuthResource{}
+}
+
+// ThirdPartyAuthResource manages a third-party auth integration of a project.
+type ThirdPartyAuthResource struct {
+	client       *api.ClientWithResponses
+	providerData *settings.SupabaseProviderData
+}
+
+type ThirdPartyAuthResourceModel struct {
+	ProjectRef            types.String `tfsdk:"project_ref"`
+	Id                    types.String `tfsdk:"id"`
+	FirebaseProjectId     types.String `tfsdk:"firebase_project_id"`
+	Auth0Tenant           types.String `tfsdk:"auth0_tenant"`
+	Auth0TenantRegion     types.String `tfsdk:"auth0_tenant_region"`
+	CognitoUserPoolId     types.String `tfsdk:"cognito_user_pool_id"`
+	CognitoUserPoolRegion types.String `tfsdk:"cognito_user_pool_region"`
+	ClerkDomain           types.String `tfsdk:"clerk_domain"`
+	OidcIssuerUrl         types.String `tfsdk:"oidc_issuer_url"`
+	JwksUrl               types.String `tfsdk:"jwks_url"`
+	Type                  types.String `tfsdk:"type"`
+	ResolvedAt            types.String `tfsdk:"resolved_at"`
+	InsertedAt            types.String `tfsdk:"inserted_at"`
+	UpdatedAt             types.String `tfsdk:"updated_at"`
+}
+
+func (r *ThirdPartyAuthResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_third_party_auth"
+}
+
+func (r *ThirdPartyAuthResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Integrations cannot be updated in place, so every input forces a new one.
+	requiresReplace := []planmodifier.String{
+		thirdPartyAuthInputModifier{},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `
+
+Manages a third-party auth integration of a Supabase project. Exactly one of ` + "`firebase_project_id`" + `, ` + "`auth0_tenant`" + `, ` + "`cognito_user_pool_id`" + `, ` + "`clerk_domain`" + `, ` + "`oidc_issuer_url`" + `, or ` + "`jwks_url`" + ` must be set.
+
+Refer to the [Supabase third-party auth documentation](https://supabase.com/docs/guides/auth/third-party/overview) for more information.
+
+## Example Usage
+
+~~~hcl
+resource "supabase_third_party_auth" "clerk" {
+  project_ref  = "abcdefghijklmnopqrst"
+  clerk_domain = "clerk.example.com"
+}
+~~~
+`,
+		Attributes: map[string]schema.Attribute{
+			"project_ref": schema.StringAttribute{
+				MarkdownDescription: "Project reference ID. Defaults to the provider `project_ref`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Integration ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"firebase_project_id": schema.StringAttribute{
+				MarkdownDescription: "Firebase project ID",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"auth0_tenant": schema.StringAttribute{
+				MarkdownDescription: "Auth0 tenant name",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"auth0_tenant_region": schema.StringAttribute{
+				MarkdownDescription: "Auth0 tenant region (e.g. `us`, `eu`), if the tenant domain includes one",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("auth0_tenant")),
+				},
+			},
+			"cognito_user_pool_id": schema.StringAttribute{
+				MarkdownDescription: "AWS Cognito user pool ID",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"cognito_user_pool_region": schema.StringAttribute{
+				MarkdownDescription: "AWS region of the Cognito user pool",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"clerk_domain": schema.StringAttribute{
+				MarkdownDescription: "Clerk frontend API domain (e.g. `clerk.example.com`)",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"oidc_issuer_url": schema.StringAttribute{
+				MarkdownDescription: "OIDC issuer URL. Computed from the provider specific attributes when those are used.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"jwks_url": schema.StringAttribute{
+				MarkdownDescription: "URL of a JWKS used to verify tokens, for issuers without OIDC discovery",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Integration type detected by Supabase",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"resolved_at": schema.StringAttribute{
+				MarkdownDescription: "When the signing keys of the issuer were last resolved",
+				Computed:            true,
+			},
+			"inserted_at": schema.StringAttribute{
+				MarkdownDescription: "When the integration was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "When the integration was last updated",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *ThirdPartyAuthResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("firebase_project_id"),
+			path.MatchRoot("auth0_tenant"),
+			path.MatchRoot("cognito_user_pool_id"),
+			path.MatchRoot("clerk_domain"),
+			path.MatchRoot("oidc_issuer_url"),
+			path.MatchRoot("jwks_url"),
+		),
+		resourcevalidator.RequiredTogether(
+			path.MatchRoot("cognito_user_pool_id"),
+			path.MatchRoot("cognito_user_pool_region"),
+		),
+	}
+}
+
+func (r *ThirdPartyAuthResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*settings.SupabaseProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *settings.SupabaseProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(providerData.RequireManagementAPI("supabase_third_party_auth")...)
+	r.client = providerData.ManagementClient
+	r.providerData = providerData
+}
+
+// ModifyPlan falls back to the provider project_ref when the resource omits it.
+func (r *ThirdPartyAuthResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.providerData.PlanProjectRef(ctx, req, resp)
+}
+
+func (r *ThirdPartyAuthResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(settings.SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	var data ThirdPartyAuthResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := api.CreateThirdPartyAuthBody{}
+	if issuer := thirdPartyAuthIssuerURL(&data); issuer != "" {
+		body.OidcIssuerUrl = &issuer
+	}
+	if !data.JwksUrl.IsNull() && !data.JwksUrl.IsUnknown() {
+		body.JwksUrl = data.JwksUrl.ValueStringPointer()
+	}
+
+	httpResp, err := r.client.CreateTPAForProjectWithResponse(ctx, data.ProjectRef.ValueString(), body)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create third-party auth integration: %s", err))
+		return
+	}
+
+	if httpResp.JSON201 == nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create third-party auth integration, got status %d: %s", httpResp.StatusCode(), httpResp.Body))
+		return
+	}
+
+	updateDataFromThirdPartyAuth(&data, httpResp.JSON201)
+
+	tflog.Trace(ctx, "created third-party auth integration")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ThirdPartyAuthResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Skipped resources keep their prior state
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
+	var data ThirdPartyAuthResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.GetTPAForProjectWithResponse(ctx, data.ProjectRef.ValueString(), data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read third-party auth integration: %s", err))
+		return
+	}
+
+	if httpResp.StatusCode() == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if httpResp.JSON200 == nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to read third-party auth integration, got status %d: %s", httpResp.StatusCode(), httpResp.Body))
+		return
+	}
+
+	// The first read after an import has no type yet, nor the provider
+	// specific attributes, which are derived from the issuer instead
+	if data.Type.IsNull() {
+		setThirdPartyAuthInputs(&data, httpResp.JSON200)
+	}
+	updateDataFromThirdPartyAuth(&data, httpResp.JSON200)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ThirdPartyAuthResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		resp.Diagnostics.Append(settings.SetSkippedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	// Every configurable attribute forces replacement, so there is nothing to
+	// send to the API here.
+	var data ThirdPartyAuthResourceModel
+	var state ThirdPartyAuthResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ResolvedAt = state.ResolvedAt
+	data.UpdatedAt = state.UpdatedAt
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ThirdPartyAuthResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.providerData.SkipsManagementAPI() {
+		return
+	}
+
+	var data ThirdPartyAuthResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.DeleteTPAForProjectWithResponse(ctx, data.ProjectRef.ValueString(), data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete third-party auth integration: %s", err))
+		return
+	}
+
+	if httpResp.StatusCode() != http.StatusOK && httpResp.StatusCode() != http.StatusNotFound {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete third-party auth integration, got status %d: %s", httpResp.StatusCode(), httpResp.Body))
+		return
+	}
+}
+
+func (r *ThirdPartyAuthResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the format project_ref/id, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_ref"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+// thirdPartyAuthIssuerURL derives the OIDC issuer URL from the provider
+// specific attributes, using the same URL formats as the Supabase CLI.
+func thirdPartyAuthIssuerURL(data *ThirdPartyAuthResourceModel) string {
+	switch {
+	case !data.FirebaseProjectId.IsNull():
+		return fmt.Sprintf("https://securetoken.google.com/%s", data.FirebaseProjectId.ValueString())
+	case !data.Auth0Tenant.IsNull():
+		if region := data.Auth0TenantRegion.ValueString(); region != "" {
+			return fmt.Sprintf("https://%s.%s.auth0.com", data.Auth0Tenant.ValueString(), region)
+		}
+		return fmt.Sprintf("https://%s.auth0.com", data.Auth0Tenant.ValueString())
+	case !data.CognitoUserPoolId.IsNull():
+		return fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s", data.CognitoUserPoolRegion.ValueString(), data.CognitoUserPoolId.ValueString())
+	case !data.ClerkDomain.IsNull():
+		return "https://" + strings.TrimPrefix(data.ClerkDomain.ValueString(), "https://")
+	case !data.OidcIssuerUrl.IsNull() && !data.OidcIssuerUrl.IsUnknown():
+		return data.OidcIssuerUrl.ValueString()
+	}
+	return ""
+}
+
+// setThirdPartyAuthInputs sets the provider specific attributes that yield the
+// issuer of tpa, inverting thirdPartyAuthIssuerURL. Any other issuer is left
+// in oidc_issuer_url.
+func setThirdPartyAuthInputs(data *ThirdPartyAuthResourceModel, tpa *api.ThirdPartyAuth) {
+	if tpa.OidcIssuerUrl == nil {
+		return
+	}
+	issuer, err := url.Parse(*tpa.OidcIssuerUrl)
+	if err != nil || issuer.Scheme != "https" {
+		return
+	}
+	host := issuer.Host
+	id := strings.TrimPrefix(issuer.Path, "/")
+
+	switch {
+	case host == "securetoken.google.com" && id != "" && !strings.Contains(id, "/"):
+		data.FirebaseProjectId = types.StringValue(id)
+	case strings.HasSuffix(host, ".auth0.com") && issuer.Path == "":
+		tenant, region, hasRegion := strings.Cut(strings.TrimSuffix(host, ".auth0.com"), ".")
+		data.Auth0Tenant = types.StringValue(tenant)
+		if hasRegion {
+			data.Auth0TenantRegion = types.StringValue(region)
+		}
+	case strings.HasPrefix(host, "cognito-idp.") && strings.HasSuffix(host, ".amazonaws.com") && id != "" && !strings.Contains(id, "/"):
+		data.CognitoUserPoolId = types.StringValue(id)
+		data.CognitoUserPoolRegion = types.StringValue(strings.TrimSuffix(strings.TrimPrefix(host, "cognito-idp."), ".amazonaws.com"))
+	case tpa.Type == "clerk" && issuer.Path == "":
+		data.ClerkDomain = types.StringValue(host)
+	}
+}
+
+// thirdPartyAuthInputModifier requires replacing the integration when a
+// provider specific attribute changes. An attribute that is only missing from
+// the state, as after an import of an issuer it could not be derived from, is
+// accepted when it yields the same issuer.
+type thirdPartyAuthInputModifier struct{}
+
+func (m thirdPartyAuthInputModifier) Description(ctx context.Context) string {
+	return "Changing the value requires replacing the integration, unless it yields the current issuer."
+}
+
+func (m thirdPartyAuthInputModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m thirdPartyAuthInputModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || req.ConfigValue.IsNull() || req.PlanValue.Equal(req.StateValue) {
+		return
+	}
+
+	if req.StateValue.IsNull() && !req.PlanValue.IsUnknown() {
+		var plan ThirdPartyAuthResourceModel
+		var issuer types.String
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("oidc_issuer_url"), &issuer)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.OidcIssuerUrl = types.StringNull()
+		if derived := thirdPartyAuthIssuerURL(&plan); derived != "" && derived == issuer.ValueString() {
+			return
+		}
+	}
+
+	resp.RequiresReplace = true
+}
+
+func updateDataFromThirdPartyAuth(data *ThirdPartyAuthResourceModel, tpa *api.ThirdPartyAuth) {
+	data.Id = types.StringValue(tpa.Id)
+	data.Type = types.StringValue(tpa.Type)
+	data.OidcIssuerUrl = types.StringPointerValue(tpa.OidcIssuerUrl)
+	data.JwksUrl = types.StringPointerValue(tpa.JwksUrl)
+	data.ResolvedAt = types.StringPointerValue(tpa.ResolvedAt)
+	data.InsertedAt = types.StringValue(tpa.InsertedAt)
+	data.UpdatedAt = types.StringValue(tpa.UpdatedAt)
+}
@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAccThirdPartyAuthResource(t *testing.T) {
+	defer gock.OffAll()
+
+	integration := api.ThirdPartyAuth{
+		Id:            "tpa-123",
+		Type:          "clerk",
+		OidcIssuerUrl: Ptr("https://clerk.example.com"),
+		InsertedAt:    "2024-01-01T00:00:00Z",
+		UpdatedAt:     "2024-01-01T00:00:00Z",
+	}
+
+	// Mock API responses for third-party auth CRUD operations
+	gock.New("https://api.supabase.com").
+		Post("/v1/projects/mayuaycdtijbctgqbycg/config/auth/third-party-auth").
+		MatchType("json").
+		JSON(map[string]string{"oidc_issuer_url": "https://clerk.example.com"}).
+		Reply(http.StatusCreated).
+		JSON(integration)
+
+	gock.New("https://api.supabase.com").
+		Get("/v1/projects/mayuaycdtijbctgqbycg/config/auth/third-party-auth/tpa-123").
+		Persist().
+		Reply(http.StatusOK).
+		JSON(integration)
+
+	gock.New("https://api.supabase.com").
+		Delete("/v1/projects/mayuaycdtijbctgqbycg/config/auth/third-party-auth/tpa-123").
+		Reply(http.StatusOK).
+		JSON(integration)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+resource "supabase_third_party_auth" "test" {
+  project_ref  = "mayuaycdtijbctgqbycg"
+  clerk_domain = "clerk.example.com"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("supabase_third_party_auth.test", "id", "tpa-123"),
+					resource.TestCheckResourceAttr("supabase_third_party_auth.test", "type", "clerk"),
+					resource.TestCheckResourceAttr("supabase_third_party_auth.test", "oidc_issuer_url", "https://clerk.example.com"),
+				),
+			},
+			// ImportState testing, which derives clerk_domain from the issuer
+			{
+				ResourceName:      "supabase_third_party_auth.test",
+				ImportState:       true,
+				ImportStateId:     "mayuaycdtijbctgqbycg/tpa-123",
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestSetThirdPartyAuthInputs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tpa      api.ThirdPartyAuth
+		expected ThirdPartyAuthResourceModel
+	}{
+		{
+			name:     "Firebase",
+			tpa:      api.ThirdPartyAuth{OidcIssuerUrl: Ptr("https://securetoken.google.com/my-app")},
+			expected: ThirdPartyAuthResourceModel{FirebaseProjectId: types.StringValue("my-app")},
+		},
+		{
+			name:     "Auth0 without region",
+			tpa:      api.ThirdPartyAuth{OidcIssuerUrl: Ptr("https://acme.auth0.com")},
+			expected: ThirdPartyAuthResourceModel{Auth0Tenant: types.StringValue("acme")},
+		},
+		{
+			name:     "Auth0 with region",
+			tpa:      api.ThirdPartyAuth{OidcIssuerUrl: Ptr("https://acme.eu.auth0.com")},
+			expected: ThirdPartyAuthResourceModel{Auth0Tenant: types.StringValue("acme"), Auth0TenantRegion: types.StringValue("eu")},
+		},
+		{
+			name:     "Cognito",
+			tpa:      api.ThirdPartyAuth{OidcIssuerUrl: Ptr("https://cognito-idp.us-east-1.amazonaws.com/us-east-1_abc")},
+			expected: ThirdPartyAuthResourceModel{CognitoUserPoolId: types.StringValue("us-east-1_abc"), CognitoUserPoolRegion: types.StringValue("us-east-1")},
+		},
+		{
+			name:     "Clerk",
+			tpa:      api.ThirdPartyAuth{Type: "clerk", OidcIssuerUrl: Ptr("https://clerk.example.com")},
+			expected: ThirdPartyAuthResourceModel{ClerkDomain: types.StringValue("clerk.example.com")},
+		},
+		{
+			name: "Custom OIDC issuer",
+			tpa:  api.ThirdPartyAuth{Type: "oidc", OidcIssuerUrl: Ptr("https://issuer.example.com")},
+		},
+		{
+			name: "JWKS only",
+			tpa:  api.ThirdPartyAuth{JwksUrl: Ptr("https://example.com/jwks.json")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var data ThirdPartyAuthResourceModel
+			setThirdPartyAuthInputs(&data, &tc.tpa)
+			if data != tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, data)
+			}
+
+			// The derived attributes yield the same issuer
+			if tc.expected != (ThirdPartyAuthResourceModel{}) {
+				if issuer := thirdPartyAuthIssuerURL(&data); issuer != *tc.tpa.OidcIssuerUrl {
+					t.Errorf("Expected issuer %s, got %s", *tc.tpa.OidcIssuerUrl, issuer)
+				}
+			}
+		})
+	}
+}
+
+func TestThirdPartyAuthIssuerURL(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     ThirdPartyAuthResourceModel
+		expected string
+	}{
+		{
+			name:     "Firebase",
+			data:     ThirdPartyAuthResourceModel{FirebaseProjectId: types.StringValue("my-app")},
+			expected: "https://securetoken.google.com/my-app",
+		},
+		{
+			name:     "Auth0 without region",
+			data:     ThirdPartyAuthResourceModel{Auth0Tenant: types.StringValue("acme")},
+			expected: "https://acme.auth0.com",
+		},
+		{
+			name:     "Auth0 with region",
+			data:     ThirdPartyAuthResourceModel{Auth0Tenant: types.StringValue("acme"), Auth0TenantRegion: types.StringValue("eu")},
+			expected: "https://acme.eu.auth0.com",
+		},
+		{
+			name:     "Cognito",
+			data:     ThirdPartyAuthResourceModel{CognitoUserPoolId: types.StringValue("us-east-1_abc"), CognitoUserPoolRegion: types.StringValue("us-east-1")},
+			expected: "https://cognito-idp.us-east-1.amazonaws.com/us-east-1_abc",
+		},
+		{
+			name:     "Clerk",
+			data:     ThirdPartyAuthResourceModel{ClerkDomain: types.StringValue("clerk.example.com")},
+			expected: "https://clerk.example.com",
+		},
+		{
+			name:     "Custom OIDC issuer",
+			data:     ThirdPartyAuthResourceModel{OidcIssuerUrl: types.StringValue("https://issuer.example.com")},
+			expected: "https://issuer.example.com",
+		},
+		{
+			name:     "JWKS only",
+			data:     ThirdPartyAuthResourceModel{JwksUrl: types.StringValue("https://example.com/jwks.json")},
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := thirdPartyAuthIssuerURL(&tc.data); actual != tc.expected {
+				t.Errorf("Expected issuer URL %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestThirdPartyAuthInputModifier(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	NewThirdPartyAuthResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	newState := func(data ThirdPartyAuthResourceModel) tfsdk.State {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		if diags := state.Set(ctx, &data); diags.HasError() {
+			t.Fatalf("unable to set state: %v", diags)
+		}
+		return state
+	}
+
+	imported := newState(ThirdPartyAuthResourceModel{
+		ProjectRef:    types.StringValue("mayuaycdtijbctgqbycg"),
+		Id:            types.StringValue("tpa-123"),
+		OidcIssuerUrl: types.StringValue("https://clerk.example.com"),
+	})
+
+	testCases := []struct {
+		name        string
+		state       tfsdk.State
+		clerkDomain string
+		replace     bool
+	}{
+		{name: "same issuer after import", state: imported, clerkDomain: "clerk.example.com", replace: false},
+		{name: "other issuer after import", state: imported, clerkDomain: "clerk.other.com", replace: true},
+		{
+			name: "changed domain",
+			state: newState(ThirdPartyAuthResourceModel{
+				ClerkDomain:   types.StringValue("clerk.example.com"),
+				OidcIssuerUrl: types.StringValue("https://clerk.example.com"),
+			}),
+			clerkDomain: "clerk.other.com",
+			replace:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := newState(ThirdPartyAuthResourceModel{
+				ProjectRef:    types.StringValue("mayuaycdtijbctgqbycg"),
+				Id:            types.StringValue("tpa-123"),
+				ClerkDomain:   types.StringValue(tc.clerkDomain),
+				OidcIssuerUrl: types.StringValue("https://clerk.example.com"),
+			})
+
+			var stateValue types.String
+			tc.state.GetAttribute(ctx, path.Root("clerk_domain"), &stateValue)
+
+			req := planmodifier.StringRequest{
+				Path:        path.Root("clerk_domain"),
+				State:       tc.state,
+				Plan:        tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				StateValue:  stateValue,
+				PlanValue:   types.StringValue(tc.clerkDomain),
+				ConfigValue: types.StringValue(tc.clerkDomain),
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+			thirdPartyAuthInputModifier{}.PlanModifyString(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if resp.RequiresReplace != tc.replace {
+				t.Errorf("Expected RequiresReplace %t, got %t", tc.replace, resp.RequiresReplace)
+			}
+		})
+	}
+}
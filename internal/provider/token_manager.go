@@ -2,20 +2,40 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/supabase/cli/pkg/api"
 )
 
+// oauthRefreshMargin is how long before expiry an OAuth access token is
+// refreshed, or half of its lifetime when that is shorter
+const oauthRefreshMargin = time.Minute
+
+// oauthDefaultLifetime is the lifetime of OAuth access tokens whose exchange
+// response has no expires_in
+const oauthDefaultLifetime = time.Hour
+
 // TokenManager handles automatic token exchange for different Supabase APIs
 type TokenManager struct {
 	managementClient *api.ClientWithResponses
 	managementToken  string
+
+	// OAuth application credentials, nil when using an access token
+	oauth          *oauthCredentials
+	oauthRefreshAt time.Time
+	oauthMutex     sync.Mutex
 	
 	// Cache for project tokens
 	projectTokens map[string]*ProjectTokens
+	inflight      map[string]*tokenFetch
+	generations   map[string]uint64 // Bumped by each invalidation of a project
 	mutex         sync.RWMutex
 }
 
@@ -26,44 +46,216 @@ type ProjectTokens struct {
 	CachedAt       time.Time // When these tokens were cached
 }
 
+// tokenFetch is a fetch of project tokens shared by concurrent callers
+type tokenFetch struct {
+	done   chan struct{}
+	tokens *ProjectTokens
+	err    error
+}
+
+// oauthCredentials holds the credentials of an OAuth application
+type oauthCredentials struct {
+	client       *api.ClientWithResponses // Client without the bearer token editor
+	clientId     string
+	clientSecret string
+	refreshToken string
+	tokenFile    string // Keeps the rotated refresh token across runs, empty when unset
+}
+
 // NewTokenManager creates a new token manager
 func NewTokenManager(managementClient *api.ClientWithResponses, managementToken string) *TokenManager {
 	return &TokenManager{
 		managementClient: managementClient,
 		managementToken:  managementToken,
 		projectTokens:    make(map[string]*ProjectTokens),
+		inflight:         make(map[string]*tokenFetch),
+		generations:      make(map[string]uint64),
 	}
 }
 
 // GetServiceRoleToken returns the service role JWT token for a project
 // This token can be used for storage API operations
 func (tm *TokenManager) GetServiceRoleToken(ctx context.Context, projectRef string) (string, error) {
+	tm.mutex.Lock()
+
 	// Check if we have a cached token that's still fresh (cache for 1 hour)
-	tm.mutex.RLock()
 	tokens, exists := tm.projectTokens[projectRef]
 	if exists && time.Since(tokens.CachedAt) < time.Hour && tokens.ServiceRoleKey != "" {
-		defer tm.mutex.RUnlock()
+		tm.mutex.Unlock()
 		return tokens.ServiceRoleKey, nil
 	}
-	tm.mutex.RUnlock()
 
-	// Fetch fresh tokens from the management API
-	tokens, err := tm.fetchProjectTokens(ctx, projectRef)
-	if err != nil {
-		return "", fmt.Errorf("failed to fetch project tokens: %w", err)
+	// Share a fetch that is already in flight for the same project
+	if fetch, ok := tm.inflight[projectRef]; ok {
+		tm.mutex.Unlock()
+		select {
+		case <-fetch.done:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		if fetch.err != nil {
+			return "", fmt.Errorf("failed to fetch project tokens: %w", fetch.err)
+		}
+		return fetch.tokens.ServiceRoleKey, nil
 	}
 
-	// Cache the tokens
+	fetch := &tokenFetch{done: make(chan struct{})}
+	tm.inflight[projectRef] = fetch
+	generation := tm.generations[projectRef]
+	tm.mutex.Unlock()
+
+	// Fetch fresh tokens from the management API. Other callers wait for this
+	// fetch, so it must not be canceled along with the first caller.
+	fetch.tokens, fetch.err = tm.fetchProjectTokens(context.WithoutCancel(ctx), projectRef)
+
+	// Cache the tokens, unless they were invalidated during the fetch and may
+	// therefore be the rotated ones
 	tm.mutex.Lock()
-	tm.projectTokens[projectRef] = tokens
+	if fetch.err == nil && tm.generations[projectRef] == generation {
+		tm.projectTokens[projectRef] = fetch.tokens
+	}
+	if tm.inflight[projectRef] == fetch {
+		delete(tm.inflight, projectRef)
+	}
 	tm.mutex.Unlock()
+	close(fetch.done)
+
+	if fetch.err != nil {
+		return "", fmt.Errorf("failed to fetch project tokens: %w", fetch.err)
+	}
+
+	return fetch.tokens.ServiceRoleKey, nil
+}
+
+// SetOAuthCredentials makes the token manager obtain management tokens by
+// exchanging the refresh token of an OAuth application. The client must not
+// add a bearer token to its requests. When tokenFile is set, the refresh token
+// is read from it before each exchange and the rotated one is written back.
+func (tm *TokenManager) SetOAuthCredentials(client *api.ClientWithResponses, clientId, clientSecret, refreshToken, tokenFile string) {
+	tm.oauthMutex.Lock()
+	defer tm.oauthMutex.Unlock()
 
-	return tokens.ServiceRoleKey, nil
+	tm.oauth = &oauthCredentials{
+		client:       client,
+		clientId:     clientId,
+		clientSecret: clientSecret,
+		refreshToken: refreshToken,
+		tokenFile:    tokenFile,
+	}
+	tm.managementToken = ""
+	tm.oauthRefreshAt = time.Time{}
+}
+
+// GetManagementToken returns the management API token, refreshing it first
+// when it comes from an OAuth application and is about to expire
+func (tm *TokenManager) GetManagementToken(ctx context.Context) (string, error) {
+	tm.oauthMutex.Lock()
+	defer tm.oauthMutex.Unlock()
+
+	if tm.oauth == nil {
+		return tm.managementToken, nil
+	}
+
+	if tm.managementToken != "" && time.Now().Before(tm.oauthRefreshAt) {
+		return tm.managementToken, nil
+	}
+
+	if err := tm.refreshOAuthToken(ctx); err != nil {
+		return "", fmt.Errorf("failed to refresh OAuth access token: %w", err)
+	}
+	return tm.managementToken, nil
 }
 
-// GetManagementToken returns the management API token
-func (tm *TokenManager) GetManagementToken() string {
-	return tm.managementToken
+// refreshOAuthToken exchanges the refresh token for a new access token. The
+// caller must hold oauthMutex, so that concurrent requests wait for a single
+// exchange.
+func (tm *TokenManager) refreshOAuthToken(ctx context.Context) error {
+	// Another provider process, such as the one that planned this apply, may
+	// have rotated the refresh token since it was read
+	if tm.oauth.tokenFile != "" {
+		refreshToken, err := readRefreshTokenFile(tm.oauth.tokenFile)
+		if err != nil {
+			return err
+		}
+		tm.oauth.refreshToken = refreshToken
+	}
+
+	resp, err := tm.oauth.client.V1ExchangeOauthTokenWithFormdataBodyWithResponse(ctx, api.V1ExchangeOauthTokenFormdataRequestBody{
+		GrantType:    api.RefreshToken,
+		ClientId:     tm.oauth.clientId,
+		ClientSecret: tm.oauth.clientSecret,
+		RefreshToken: &tm.oauth.refreshToken,
+	})
+	if err != nil {
+		return fmt.Errorf("API request failed: %w", err)
+	}
+
+	if resp.StatusCode() != 201 && resp.StatusCode() != 200 {
+		return fmt.Errorf("API returned status %d: %s", resp.StatusCode(), string(resp.Body))
+	}
+
+	// The generated client only decodes 201 responses
+	var token api.OAuthTokenResponse
+	if err := json.Unmarshal(resp.Body, &token); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return fmt.Errorf("no access token in response")
+	}
+
+	lifetime := time.Duration(token.ExpiresIn) * time.Second
+	if lifetime <= 0 {
+		lifetime = oauthDefaultLifetime
+	}
+	tm.managementToken = token.AccessToken
+	tm.oauthRefreshAt = time.Now().Add(lifetime - min(oauthRefreshMargin, lifetime/2))
+
+	// Refresh tokens rotate, and the exchange invalidates the previous one,
+	// so the latest one is kept for the next refresh
+	if token.RefreshToken != "" && token.RefreshToken != tm.oauth.refreshToken {
+		tm.oauth.refreshToken = token.RefreshToken
+		if tm.oauth.tokenFile != "" {
+			if err := writeRefreshTokenFile(tm.oauth.tokenFile, token.RefreshToken); err != nil {
+				return fmt.Errorf("failed to save the rotated refresh token to %s: %w", tm.oauth.tokenFile, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// readRefreshTokenFile reads an OAuth refresh token from a file.
+func readRefreshTokenFile(tokenFile string) (string, error) {
+	content, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the refresh token: %w", err)
+	}
+
+	refreshToken := strings.TrimSpace(string(content))
+	if refreshToken == "" {
+		return "", errors.New("the refresh token file is empty")
+	}
+	return refreshToken, nil
+}
+
+// writeRefreshTokenFile replaces the refresh token in a file. The token is
+// written to a temporary file first, so that the previous one is kept when
+// writing fails.
+func writeRefreshTokenFile(tokenFile string, refreshToken string) error {
+	temp, err := os.CreateTemp(filepath.Dir(tokenFile), filepath.Base(tokenFile)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.WriteString(refreshToken + "\n"); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), tokenFile)
 }
 
 // fetchProjectTokens fetches API keys from the management API
@@ -104,9 +296,13 @@ func (tm *TokenManager) fetchProjectTokens(ctx context.Context, projectRef strin
 }
 
 // InvalidateProjectTokens clears the cached tokens for a project
-// This can be called if authentication fails to force a refresh
+// This can be called if authentication fails to force a refresh. A fetch in
+// flight may return the invalidated tokens, so its result is not cached and
+// later callers start a new fetch.
 func (tm *TokenManager) InvalidateProjectTokens(projectRef string) {
 	tm.mutex.Lock()
 	delete(tm.projectTokens, projectRef)
+	delete(tm.inflight, projectRef)
+	tm.generations[projectRef]++
 	tm.mutex.Unlock()
 }
\ No newline at end of file
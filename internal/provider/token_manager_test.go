@@ -0,0 +1,313 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/supabase/cli/pkg/api"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestTokenManagerOAuthRefresh(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New("https://api.supabase.com").
+		Post("/v1/oauth/token").
+		MatchType("url").
+		BodyString("client_id=client-123&client_secret=secret-456&grant_type=refresh_token&refresh_token=refresh-1").
+		Reply(http.StatusCreated).
+		JSON(map[string]interface{}{
+			"access_token":  "sbp_oauth_first",
+			"refresh_token": "refresh-2",
+			"expires_in":    3600,
+			"token_type":    "Bearer",
+		})
+
+	// The rotated refresh token is used for the next exchange
+	gock.New("https://api.supabase.com").
+		Post("/v1/oauth/token").
+		MatchType("url").
+		BodyString("client_id=client-123&client_secret=secret-456&grant_type=refresh_token&refresh_token=refresh-2").
+		Reply(http.StatusCreated).
+		JSON(map[string]interface{}{
+			"access_token":  "sbp_oauth_second",
+			"refresh_token": "refresh-3",
+			"expires_in":    3600,
+			"token_type":    "Bearer",
+		})
+
+	client, err := api.NewClientWithResponses("https://api.supabase.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tm := NewTokenManager(client, "")
+	tm.SetOAuthCredentials(client, "client-123", "secret-456", "refresh-1", "")
+
+	// The second call is served from the cache
+	for i := 0; i < 2; i++ {
+		token, err := tm.GetManagementToken(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if token != "sbp_oauth_first" {
+			t.Errorf("Expected first token, got %s", token)
+		}
+	}
+
+	// Tokens are refreshed shortly before they expire
+	if until := time.Until(tm.oauthRefreshAt); until < 58*time.Minute || until > time.Hour {
+		t.Errorf("Expected a refresh a minute before expiry, got %s", until)
+	}
+	tm.oauthRefreshAt = time.Now()
+
+	token, err := tm.GetManagementToken(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if token != "sbp_oauth_second" {
+		t.Errorf("Expected refreshed token, got %s", token)
+	}
+
+	if !gock.IsDone() {
+		t.Errorf("Expected both token exchanges to be made")
+	}
+}
+
+func TestTokenManagerOAuthRefreshTokenFile(t *testing.T) {
+	defer gock.OffAll()
+
+	tokenFile := filepath.Join(t.TempDir(), "refresh_token")
+	if err := os.WriteFile(tokenFile, []byte("refresh-1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// A 200 response without expires_in is accepted
+	gock.New("https://api.supabase.com").
+		Post("/v1/oauth/token").
+		MatchType("url").
+		BodyString("client_id=client-123&client_secret=secret-456&grant_type=refresh_token&refresh_token=refresh-1").
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{
+			"access_token":  "sbp_oauth_first",
+			"refresh_token": "refresh-2",
+			"token_type":    "Bearer",
+		})
+
+	client, err := api.NewClientWithResponses("https://api.supabase.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tm := NewTokenManager(client, "")
+	tm.SetOAuthCredentials(client, "client-123", "secret-456", "refresh-1", tokenFile)
+
+	token, err := tm.GetManagementToken(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if token != "sbp_oauth_first" {
+		t.Errorf("Expected first token, got %s", token)
+	}
+
+	// Without expires_in, the token is kept instead of refreshed on every request
+	if time.Until(tm.oauthRefreshAt) < 58*time.Minute {
+		t.Errorf("Expected the default lifetime, got a refresh in %s", time.Until(tm.oauthRefreshAt))
+	}
+
+	// The rotated refresh token is kept for the next run
+	refreshToken, err := readRefreshTokenFile(tokenFile)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if refreshToken != "refresh-2" {
+		t.Errorf("Expected the rotated refresh token in the file, got %s", refreshToken)
+	}
+}
+
+func TestTokenManagerOAuthRefreshFailure(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New("https://api.supabase.com").
+		Post("/v1/oauth/token").
+		Reply(http.StatusBadRequest).
+		JSON(map[string]string{"message": "invalid refresh token"})
+
+	client, err := api.NewClientWithResponses("https://api.supabase.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tm := NewTokenManager(client, "")
+	tm.SetOAuthCredentials(client, "client-123", "secret-456", "revoked", "")
+
+	if _, err := tm.GetManagementToken(context.Background()); err == nil {
+		t.Errorf("Expected refresh error")
+	}
+}
+
+func TestTokenManagerAccessToken(t *testing.T) {
+	tm := NewTokenManager(nil, "sbp_personal")
+
+	token, err := tm.GetManagementToken(context.Background())
+	if err != nil || token != "sbp_personal" {
+		t.Errorf("Expected personal access token, got %s %v", token, err)
+	}
+}
+
+func TestTokenManagerSharesInflightFetch(t *testing.T) {
+	defer gock.OffAll()
+
+	// Only one fetch is mocked, a second one would fail
+	gock.New("https://api.supabase.com").
+		Get("/v1/projects/mayuaycdtijbctgqbycg/api-keys").
+		Times(1).
+		Reply(http.StatusOK).
+		Delay(200 * time.Millisecond).
+		JSON([]map[string]interface{}{
+			{"name": "service_role", "api_key": "service-role-key"},
+		})
+
+	client, err := api.NewClientWithResponses("https://api.supabase.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tm := NewTokenManager(client, "")
+
+	var wg sync.WaitGroup
+	tokens := make([]string, 8)
+	errs := make([]error, 8)
+	for i := range tokens {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tokens[i], errs[i] = tm.GetServiceRoleToken(context.Background(), "mayuaycdtijbctgqbycg")
+		}()
+	}
+	wg.Wait()
+
+	for i := range tokens {
+		if errs[i] != nil {
+			t.Fatalf("Unexpected error: %s", errs[i])
+		}
+		if tokens[i] != "service-role-key" {
+			t.Errorf("Expected service role key, got %s", tokens[i])
+		}
+	}
+
+	if !gock.IsDone() {
+		t.Errorf("Expected the keys to be fetched")
+	}
+}
+
+// waitForInflightFetch waits until a fetch of the project tokens is in flight
+func waitForInflightFetch(t *testing.T, tm *TokenManager, projectRef string) {
+	t.Helper()
+	for range 100 {
+		tm.mutex.RLock()
+		_, ok := tm.inflight[projectRef]
+		tm.mutex.RUnlock()
+		if ok {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Expected a fetch in flight")
+}
+
+func TestTokenManagerInvalidateDuringFetch(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New("https://api.supabase.com").
+		Get("/v1/projects/mayuaycdtijbctgqbycg/api-keys").
+		Times(1).
+		Reply(http.StatusOK).
+		Delay(200 * time.Millisecond).
+		JSON([]map[string]interface{}{
+			{"name": "service_role", "api_key": "revoked-service-role-key"},
+		})
+	gock.New("https://api.supabase.com").
+		Get("/v1/projects/mayuaycdtijbctgqbycg/api-keys").
+		Times(1).
+		Reply(http.StatusOK).
+		JSON([]map[string]interface{}{
+			{"name": "service_role", "api_key": "rotated-service-role-key"},
+		})
+
+	client, err := api.NewClientWithResponses("https://api.supabase.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tm := NewTokenManager(client, "")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		tm.GetServiceRoleToken(context.Background(), "mayuaycdtijbctgqbycg")
+	}()
+	waitForInflightFetch(t, tm, "mayuaycdtijbctgqbycg")
+	tm.InvalidateProjectTokens("mayuaycdtijbctgqbycg")
+	<-done
+
+	// The fetch that was invalidated must not be cached
+	token, err := tm.GetServiceRoleToken(context.Background(), "mayuaycdtijbctgqbycg")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if token != "rotated-service-role-key" {
+		t.Errorf("Expected the rotated service role key, got %s", token)
+	}
+
+	if !gock.IsDone() {
+		t.Errorf("Expected the keys to be fetched twice")
+	}
+}
+
+func TestTokenManagerSharedFetchOutlivesFirstCaller(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New("https://api.supabase.com").
+		Get("/v1/projects/mayuaycdtijbctgqbycg/api-keys").
+		Times(1).
+		Reply(http.StatusOK).
+		Delay(200 * time.Millisecond).
+		JSON([]map[string]interface{}{
+			{"name": "service_role", "api_key": "service-role-key"},
+		})
+
+	client, err := api.NewClientWithResponses("https://api.supabase.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tm := NewTokenManager(client, "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go tm.GetServiceRoleToken(ctx, "mayuaycdtijbctgqbycg")
+	waitForInflightFetch(t, tm, "mayuaycdtijbctgqbycg")
+
+	var token string
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		token, err = tm.GetServiceRoleToken(context.Background(), "mayuaycdtijbctgqbycg")
+	}()
+
+	// Canceling the caller that started the fetch must not fail the others
+	cancel()
+	<-done
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if token != "service-role-key" {
+		t.Errorf("Expected service role key, got %s", token)
+	}
+}